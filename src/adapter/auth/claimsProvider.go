@@ -8,13 +8,14 @@ type (
 	claimsProvider struct {
 		email         string
 		userID        string
+		timeZone      string
 		authenticated bool
 	}
 )
 
 // NewClaimsProvider is create instance
-func NewClaimsProvider(email string, userID string, authenticated bool) core.ClaimsProvider {
-	return &claimsProvider{email, userID, authenticated}
+func NewClaimsProvider(email string, userID string, timeZone string, authenticated bool) core.ClaimsProvider {
+	return &claimsProvider{email, userID, timeZone, authenticated}
 }
 
 // NewAnonymousClaimsProvider is create instance
@@ -24,6 +25,9 @@ func NewAnonymousClaimsProvider() core.ClaimsProvider {
 func (t *claimsProvider) GetEmail() *string {
 	return &t.email
 }
+func (t *claimsProvider) GetTimeZone() *string {
+	return &t.timeZone
+}
 func (t *claimsProvider) GetUserID() *string {
 	return &t.userID
 }
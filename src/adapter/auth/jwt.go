@@ -21,6 +21,7 @@ type (
 		Email         string `json:"email"`
 		EmailVerified bool   `json:"email_verified"`
 		Locale        string `json:"locale"`
+		TimeZone      string `json:"zoneinfo,omitempty"`
 		jwt.StandardClaims
 	}
 	customRefreshClaims struct {
@@ -45,6 +46,7 @@ func (t *j) CreateToken(claims *application.JwtClaims) (*string, error) {
 		Email:         claims.Email,
 		EmailVerified: true,
 		Locale:        claims.Culture,
+		TimeZone:      claims.TimeZone,
 		StandardClaims: jwt.StandardClaims{
 			Issuer:    "prj-account-book.appspot.com",
 			Subject:   *url,
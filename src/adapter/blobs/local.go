@@ -0,0 +1,49 @@
+package blobs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	local struct {
+		env            application.Env
+		claimsProvider core.ClaimsProvider
+	}
+)
+
+// NewLocal はローカルファイルシステムに保存する BlobStore を生成します
+func NewLocal(env application.Env, claimsProvider core.ClaimsProvider) application.BlobStore {
+	return &local{env, claimsProvider}
+}
+func (t *local) path(key string) string {
+	userID := t.claimsProvider.GetUserID()
+	return filepath.Join(*t.env.GetBlobStorePath(), "users", *userID, filepath.FromSlash(key))
+}
+func (t *local) Put(key string, data []byte) error {
+	path := t.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+func (t *local) Get(key string) (*[]byte, error) {
+	data, err := ioutil.ReadFile(t.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, core.NewError(application.NotFound)
+		}
+		return nil, err
+	}
+	return &data, nil
+}
+func (t *local) Delete(key string) error {
+	if err := os.Remove(t.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -16,11 +16,15 @@ type (
 		jwtSecret           *[]byte
 		passwordHashedKey   *[]byte
 		sendGridAPIKey      *string
+		alertTemplateID     *string
+		weeklyTemplateID    *string
+		monthEndTemplateID  *string
 		frontEndURL         *string
 		awsAccessKey        *string
 		awsSecretAccessKey  *string
 		awsTopics           *map[core.EventName]application.AwsTopicArn
 		awsQueues           *map[core.QueueName]application.AwsQueueURL
+		blobStorePath       *string
 	}
 	awsTopic struct {
 		Name string `json:"name"`
@@ -56,12 +60,23 @@ func (t *env) Initialize() error {
 	t.jwtSecret = &jwtSecret
 	sendGridAPIKey := os.Getenv("SENDGRID_API_KEY")
 	t.sendGridAPIKey = &sendGridAPIKey
+	alertTemplateID := os.Getenv("SENDGRID_ALERT_TEMPLATE_ID")
+	t.alertTemplateID = &alertTemplateID
+	weeklyTemplateID := os.Getenv("SENDGRID_WEEKLY_DIGEST_TEMPLATE_ID")
+	t.weeklyTemplateID = &weeklyTemplateID
+	monthEndTemplateID := os.Getenv("SENDGRID_MONTH_END_DIGEST_TEMPLATE_ID")
+	t.monthEndTemplateID = &monthEndTemplateID
 	frontEndURL := os.Getenv("FRONT_END_URL")
 	t.frontEndURL = &frontEndURL
 	awsAccessKey := os.Getenv("AWS_ACCESS_KEY")
 	t.awsAccessKey = &awsAccessKey
 	awsSecretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
 	t.awsSecretAccessKey = &awsSecretAccessKey
+	blobStorePath := os.Getenv("BLOB_STORE_PATH")
+	if blobStorePath == "" {
+		blobStorePath = "./.blobs"
+	}
+	t.blobStorePath = &blobStorePath
 	if err := t.setAwsTopics(); err != nil {
 		return err
 	}
@@ -99,6 +114,15 @@ func (t *env) GetCredentialsFilePath() *string {
 func (t *env) GetSendGridAPIKey() *string {
 	return t.sendGridAPIKey
 }
+func (t *env) GetAlertTemplateID() *string {
+	return t.alertTemplateID
+}
+func (t *env) GetWeeklyDigestTemplateID() *string {
+	return t.weeklyTemplateID
+}
+func (t *env) GetMonthEndDigestTemplateID() *string {
+	return t.monthEndTemplateID
+}
 func (t *env) GetFrontEndURL() *string {
 	return t.frontEndURL
 }
@@ -123,6 +147,9 @@ func (t *env) GetAwsTopics() *map[core.EventName]application.AwsTopicArn {
 func (t *env) GetAwsQueues() *map[core.QueueName]application.AwsQueueURL {
 	return t.awsQueues
 }
+func (t *env) GetBlobStorePath() *string {
+	return t.blobStorePath
+}
 func (t *env) GetAllowOrigins() *[]string {
 	if t.isProduction {
 		return &[]string{*t.frontEndURL}
@@ -1,11 +1,13 @@
 package handler
 
-import "github.com/wakuwaku3/account-book.api/src/enterprise/core"
+import (
+	"github.com/labstack/gommon/log"
 
-import "fmt"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
 
 type (
-	alert struct{ provider core.ClaimsProvider }
+	alert struct{ useCase usecases.Alerts }
 	// Alert は ハンドラーです
 	Alert interface {
 		Notify(arg *NotifyArgs) error
@@ -19,16 +21,22 @@ type (
 )
 
 // NewAlert はインスタンスを生成します
-func NewAlert(provider core.ClaimsProvider) Alert {
-	return &alert{provider}
+func NewAlert(useCase usecases.Alerts) Alert {
+	return &alert{useCase}
 }
+
+// Notify は入出金状態の変更を受けて、ユーザーの通知ルールを評価します
 func (t *alert) Notify(arg *NotifyArgs) error {
-	userID := *t.provider.GetUserID()
-	fmt.Println(*arg)
-	fmt.Println(userID)
+	res, err := t.useCase.Evaluate()
+	if err != nil {
+		return err
+	}
+	if len(res.NotificationRuleIDs) > 0 {
+		log.Infof("Notify id:%s, userID:%s, rules:%v", arg.ID, arg.UserID, res.NotificationRuleIDs)
+	}
 	return nil
 }
 func (t *alert) NotifyDeadLetter(body *string) error {
-	fmt.Println(*body)
+	log.Warn(*body)
 	return nil
 }
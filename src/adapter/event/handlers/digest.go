@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/labstack/gommon/log"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type (
+	digest struct{ useCase usecases.Digests }
+	// Digest は ハンドラーです
+	Digest interface {
+		GetSubscriberIDs() ([]string, error)
+		Send(userID string) error
+	}
+)
+
+// NewDigest はインスタンスを生成します
+func NewDigest(useCase usecases.Digests) Digest {
+	return &digest{useCase}
+}
+
+// GetSubscriberIDs はまとめメールを受け取るユーザーの ID を返します
+func (t *digest) GetSubscriberIDs() ([]string, error) {
+	return t.useCase.GetSubscriberIDs()
+}
+
+// Send はユーザーに今日送るまとめメールを送ります
+func (t *digest) Send(userID string) error {
+	res, err := t.useCase.Send()
+	if err != nil {
+		return err
+	}
+	if res.Weekly || res.MonthEnd {
+		log.Infof("SendDigests userID:%s, weekly:%t, monthEnd:%t", userID, res.Weekly, res.MonthEnd)
+	}
+	return nil
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 
+	"github.com/labstack/gommon/log"
 	"github.com/tampopos/dijct"
+	"github.com/wakuwaku3/account-book.api/src/adapter/auth"
 	handler "github.com/wakuwaku3/account-book.api/src/adapter/event/handlers"
+	"github.com/wakuwaku3/account-book.api/src/adapter/system"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 )
 
@@ -37,12 +41,66 @@ func NewRouter() Router {
 		if err := unmarshalJSON(message, &args); err != nil {
 			return err
 		}
-		return container.Invoke(func(alert handler.Alert) error {
+		child, err := userContainer(container, args.UserID)
+		if err != nil {
+			return err
+		}
+		return child.Invoke(func(alert handler.Alert) error {
 			return alert.Notify(&args)
 		})
 	})
+	// SendDigests は定期的(1 時間毎など)に送られ、購読しているユーザー毎にまとめメールを送ります
+	instance.Route("SendDigests", func(container dijct.Container, message *string) error {
+		var ids []string
+		if err := container.Invoke(func(digest handler.Digest) error {
+			res, err := digest.GetSubscriberIDs()
+			ids = res
+			return err
+		}); err != nil {
+			return err
+		}
+		// 一部のユーザーの失敗で他のユーザーへの送信を止めないよう、記録して続けます
+		// 失敗したユーザーがいる場合はエラーを返して再配信させ、送信済みのメールは送信日時により再送しません
+		failed := 0
+		for _, id := range ids {
+			if err := sendDigest(container, id); err != nil {
+				log.Errorf("SendDigests userID:%s, error:%v", id, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d 人のユーザーへのまとめメールの送信に失敗しました", failed)
+		}
+		return nil
+	})
 	return instance
 }
+func sendDigest(container dijct.Container, userID string) error {
+	child, err := userContainer(container, userID)
+	if err != nil {
+		return err
+	}
+	return child.Invoke(func(digest handler.Digest) error {
+		return digest.Send(userID)
+	})
+}
+
+// userContainer はメッセージのユーザーとして処理するための子コンテナを生成します
+func userContainer(container dijct.Container, userID string) (dijct.Container, error) {
+	if userID == "" {
+		return nil, errors.New("userIDが存在しないため処理できません")
+	}
+	child := container.CreateChildContainer()
+	claimsProvider := auth.NewClaimsProvider("", userID, "", true)
+	ifs := []reflect.Type{reflect.TypeOf((*core.ClaimsProvider)(nil)).Elem()}
+	if err := child.Register(claimsProvider, dijct.RegisterOptions{Interfaces: ifs}); err != nil {
+		return nil, err
+	}
+	if err := system.RegisterUserClock(child, claimsProvider); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
 func unmarshalJSON(message *string, obj interface{}) error {
 	if message == nil {
 		return errors.New("messageが存在しないためUnmarshalできません")
@@ -0,0 +1,87 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	// gif をデコードできるようにします
+	_ "image/gif"
+	"image/jpeg"
+	// png をデコードできるようにします
+	_ "image/png"
+	"strings"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+)
+
+type (
+	thumbnailer struct{}
+)
+
+const (
+	// thumbnailSize はサムネイルの長辺の長さです
+	thumbnailSize = 240
+	// maxPixels はデコードする画像の画素数の上限です
+	// 小さなファイルで巨大な画像を宣言してメモリを使い切らせないよう、デコード前に検証します
+	maxPixels = 40 * 1000 * 1000
+)
+
+// NewThumbnailer はインスタンスを生成します
+func NewThumbnailer() application.Thumbnailer {
+	return &thumbnailer{}
+}
+
+// Create は画像を縮小した jpeg を生成します。画像でない場合は nil を返します
+func (t *thumbnailer) Create(contentType string, data []byte) (*[]byte, error) {
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, nil
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, nil
+	}
+	if config.Width > maxPixels/config.Height {
+		return nil, fmt.Errorf("画像のサイズ(%dx%d)が大きすぎます", config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, nil
+	}
+	w, h := width, height
+	if w > thumbnailSize || h > thumbnailSize {
+		if w >= h {
+			w, h = thumbnailSize, height*thumbnailSize/width
+		} else {
+			w, h = width*thumbnailSize/height, thumbnailSize
+		}
+		if w == 0 {
+			w = 1
+		}
+		if h == 0 {
+			h = 1
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.Set(x, y, src.At(bounds.Min.X+x*width/w, bounds.Min.Y+y*height/h))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	return &b, nil
+}
@@ -0,0 +1,54 @@
+package mails
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/mails/sendgrid"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+)
+
+type (
+	monthEndDigest struct {
+		env    application.Env
+		helper sendgrid.Helper
+	}
+)
+
+// NewMonthEndDigest is create instance
+func NewMonthEndDigest(env application.Env, helper sendgrid.Helper) application.MonthEndDigestMail {
+	return &monthEndDigest{env, helper}
+}
+func (t *monthEndDigest) Send(args *application.MonthEndDigestMailSendArgs) error {
+	templateID := *t.env.GetMonthEndDigestTemplateID()
+	if templateID == "" {
+		return errors.New("月末のお知らせメールのテンプレートが設定されていません")
+	}
+	b := &sendgrid.RequestBody{
+		From: sendgrid.MailAddress{
+			Name:  "Account Book Support",
+			Email: "support@prj-account-book.firebaseapp.com",
+		},
+		Personalizations: []sendgrid.Personalization{
+			sendgrid.Personalization{
+				To: []sendgrid.MailAddress{
+					sendgrid.MailAddress{
+						Email: args.Email,
+					},
+				},
+				// テンプレートデータは文字列のみのため、未入力の計画は改行で区切ります
+				DynamicTemplateData: map[string]string{
+					"month":        args.Month.Format("2006/01"),
+					"missingPlans": strings.Join(args.MissingPlanNames, "\n"),
+					"missingCount": strconv.Itoa(len(args.MissingPlanNames)),
+					"canApprove":   strconv.FormatBool(args.CanApprove),
+					"url":          *t.env.GetFrontEndURL(),
+				},
+			},
+		},
+		TemplateID: templateID,
+	}
+	return t.helper.Send(b)
+}
@@ -0,0 +1,53 @@
+package mails
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/mails/sendgrid"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+)
+
+type (
+	weeklyDigest struct {
+		env    application.Env
+		helper sendgrid.Helper
+	}
+)
+
+// NewWeeklyDigest is create instance
+func NewWeeklyDigest(env application.Env, helper sendgrid.Helper) application.WeeklyDigestMail {
+	return &weeklyDigest{env, helper}
+}
+func (t *weeklyDigest) Send(args *application.WeeklyDigestMailSendArgs) error {
+	templateID := *t.env.GetWeeklyDigestTemplateID()
+	if templateID == "" {
+		return errors.New("週次のまとめメールのテンプレートが設定されていません")
+	}
+	b := &sendgrid.RequestBody{
+		From: sendgrid.MailAddress{
+			Name:  "Account Book Support",
+			Email: "support@prj-account-book.firebaseapp.com",
+		},
+		Personalizations: []sendgrid.Personalization{
+			sendgrid.Personalization{
+				To: []sendgrid.MailAddress{
+					sendgrid.MailAddress{
+						Email: args.Email,
+					},
+				},
+				DynamicTemplateData: map[string]string{
+					"start":           args.Start.Format("2006/01/02"),
+					"end":             args.End.AddDate(0, 0, -1).Format("2006/01/02"),
+					"expense":         strconv.Itoa(args.Expense),
+					"previousExpense": strconv.Itoa(args.PreviousExpense),
+					"difference":      strconv.Itoa(args.Expense - args.PreviousExpense),
+					"url":             *t.env.GetFrontEndURL(),
+				},
+			},
+		},
+		TemplateID: templateID,
+	}
+	return t.helper.Send(b)
+}
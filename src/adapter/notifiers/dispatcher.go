@@ -0,0 +1,67 @@
+package notifiers
+
+import (
+	"fmt"
+
+	"github.com/labstack/gommon/log"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	dispatcher struct {
+		settingsRepos notifications.ChannelSettingsRepository
+		email         Email
+		webhook       Webhook
+		inApp         InApp
+	}
+	deliver func(settings notifications.ChannelSettings, notification *notifications.Notification) error
+)
+
+// NewDispatcher はインスタンスを生成します
+// 通知ルールで選択された配信先へ通知を配信します
+func NewDispatcher(
+	settingsRepos notifications.ChannelSettingsRepository,
+	email Email,
+	webhook Webhook,
+	inApp InApp,
+) notifications.Notifier {
+	return &dispatcher{settingsRepos, email, webhook, inApp}
+}
+
+// Notify は全ての配信先に配信を試み、配信に失敗した配信先とエラーを返します
+// 設定されていない配信先は再配信しても配信できないため、失敗した配信先に含めません
+func (t *dispatcher) Notify(notification *notifications.Notification) ([]string, error) {
+	settings := t.settingsRepos.Get()
+	failedChannels := make([]string, 0)
+	var failed error
+	for _, channel := range notification.Channels {
+		fn := t.getDeliver(channel, settings)
+		if fn == nil {
+			log.Warnf("配信先(%s)が設定されていないため配信しません", channel)
+			continue
+		}
+		if err := fn(settings, notification); err != nil {
+			log.Error(err)
+			failedChannels = append(failedChannels, channel)
+			failed = fmt.Errorf("配信先(%s)への配信に失敗しました: %v", channel, err)
+		}
+	}
+	return failedChannels, failed
+}
+func (t *dispatcher) getDeliver(channel string, settings notifications.ChannelSettings) deliver {
+	switch channel {
+	case notifications.EmailChannel:
+		if !t.email.Configured() {
+			return nil
+		}
+		return t.email.Deliver
+	case notifications.WebhookChannel:
+		if settings.GetWebhookURL() == "" {
+			return nil
+		}
+		return t.webhook.Deliver
+	case notifications.InAppChannel:
+		return t.inApp.Deliver
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package notifiers
+
+import (
+	"errors"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/mails/sendgrid"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	email struct {
+		env    application.Env
+		helper sendgrid.Helper
+		users  application.UsersRepository
+	}
+	// Email は通知をメールで配信します
+	Email interface {
+		// Configured はメールのテンプレートが設定されているかどうかを返します
+		Configured() bool
+		Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error
+	}
+)
+
+// NewEmail はインスタンスを生成します
+func NewEmail(env application.Env, helper sendgrid.Helper, users application.UsersRepository) Email {
+	return &email{env, helper, users}
+}
+func (t *email) Configured() bool {
+	return *t.env.GetAlertTemplateID() != ""
+}
+func (t *email) Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error {
+	templateID := *t.env.GetAlertTemplateID()
+	if templateID == "" {
+		return errors.New("通知メールのテンプレートが設定されていません")
+	}
+	user, err := t.users.GetByAuth()
+	if err != nil {
+		return err
+	}
+	b := &sendgrid.RequestBody{
+		From: sendgrid.MailAddress{
+			Name:  "Account Book Support",
+			Email: "support@prj-account-book.firebaseapp.com",
+		},
+		Personalizations: []sendgrid.Personalization{
+			sendgrid.Personalization{
+				To: []sendgrid.MailAddress{
+					sendgrid.MailAddress{
+						Email: user.Email,
+					},
+				},
+				DynamicTemplateData: map[string]string{
+					"title": notification.Title(),
+					"body":  notification.Body(),
+					"url":   *t.env.GetFrontEndURL(),
+				},
+			},
+		},
+		TemplateID: templateID,
+	}
+	return t.helper.Send(b)
+}
@@ -0,0 +1,23 @@
+package notifiers
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	inApp struct {
+		repos notifications.InboxRepository
+	}
+	// InApp は通知をアプリ内の通知として保存します
+	InApp interface {
+		Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error
+	}
+)
+
+// NewInApp はインスタンスを生成します
+func NewInApp(repos notifications.InboxRepository) InApp {
+	return &inApp{repos}
+}
+func (t *inApp) Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error {
+	return t.repos.Add(notification)
+}
@@ -0,0 +1,165 @@
+package notifiers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	webhook struct {
+		clock  core.Clock
+		client *http.Client
+	}
+	// Webhook は通知を Webhook で配信します
+	Webhook interface {
+		Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error
+	}
+	// blockedAddressError は送信先がサーバー内部のアドレスのため接続しなかったエラーです
+	blockedAddressError struct {
+		host string
+	}
+	webhookPayload struct {
+		NotificationRuleID string    `json:"notificationRuleId"`
+		Metrics            string    `json:"metrics"`
+		Operator           string    `json:"operator"`
+		Period             string    `json:"period"`
+		PeriodStart        time.Time `json:"periodStart"`
+		Category           *int      `json:"category"`
+		Threshold          int       `json:"threshold"`
+		Value              int       `json:"value"`
+		Month              time.Time `json:"month"`
+		Title              string    `json:"title"`
+		Body               string    `json:"body"`
+	}
+)
+
+const (
+	// webhookMaxAttempts は Webhook の送信を試みる上限回数です
+	webhookMaxAttempts = 3
+	// webhookSignatureHeader は署名を設定するヘッダーです
+	webhookSignatureHeader = "X-AccountBook-Signature"
+	// webhookTimestampHeader は送信時刻を設定するヘッダーです
+	webhookTimestampHeader = "X-AccountBook-Timestamp"
+)
+
+// NewWebhook はインスタンスを生成します
+// 名前解決の結果がサーバー内部のアドレスの場合は接続せず、リダイレクトにも従いません
+func NewWebhook(clock core.Clock) Webhook {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !notifications.IsPublicIP(ip) {
+				return &blockedAddressError{host}
+			}
+			return nil
+		},
+	}
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return &webhook{clock, client}
+}
+
+// Deliver は通知を JSON で POST します
+// 受信側は "送信時刻.本文" をシークレットで HMAC-SHA256 した値と署名を比較して検証します
+func (t *webhook) Deliver(settings notifications.ChannelSettings, notification *notifications.Notification) error {
+	body, err := json.Marshal(&webhookPayload{
+		NotificationRuleID: *notification.NotificationRuleID,
+		Metrics:            notification.Metrics,
+		Operator:           notification.Operator,
+		Period:             notification.Period,
+		PeriodStart:        notification.PeriodStart,
+		Category:           notification.Category,
+		Threshold:          notification.Threshold,
+		Value:              notification.Value,
+		Month:              notification.Month,
+		Title:              notification.Title(),
+		Body:               notification.Body(),
+	})
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(t.clock.Now().Unix(), 10)
+	signature := sign(settings.GetWebhookSecret(), timestamp, body)
+
+	wait := time.Second
+	for attempt := 1; ; attempt++ {
+		retry, err := t.post(settings.GetWebhookURL(), timestamp, signature, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= webhookMaxAttempts {
+			return err
+		}
+		log.Warn(err)
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// post は Webhook を 1 回送信し、失敗した場合は再送すべきかどうかを返します
+func (t *webhook) post(webhookURL string, timestamp string, signature string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, "sha256="+signature)
+	res, err := t.client.Do(req)
+	if err != nil {
+		// 配信できない送信先は再送しても成功しないため再送しません
+		return !isBlockedAddress(err), err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	// クライアントエラーは再送しても成功しないため、タイムアウトと流量制限のみ再送します
+	retry := res.StatusCode >= 500 ||
+		res.StatusCode == http.StatusRequestTimeout ||
+		res.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook の送信に失敗しました status:%d", res.StatusCode)
+}
+func isBlockedAddress(err error) bool {
+	if e, ok := err.(*url.Error); ok {
+		err = e.Err
+	}
+	if e, ok := err.(*net.OpError); ok {
+		err = e.Err
+	}
+	_, ok := err.(*blockedAddressError)
+	return ok
+}
+func (t *blockedAddressError) Error() string {
+	return fmt.Sprintf("webhook の送信先(%s)には配信できません", t.host)
+}
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,185 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	aggregates struct {
+		provider       store.Provider
+		clock          core.Clock
+		claimsProvider core.ClaimsProvider
+	}
+	// aggregateUpdater は Firestore のトランザクション内で月毎の集計を更新します
+	// 読み込みは全て apply で行うため、書き込みの前に apply を済ませる必要があります
+	aggregateUpdater struct {
+		tx              *firestore.Transaction
+		aggregatesRef   *firestore.CollectionRef
+		transactionsRef *firestore.CollectionRef
+		clock           core.Clock
+		aggregates      map[string]*models.Aggregate
+	}
+)
+
+// NewAggregates はインスタンスを生成します
+func NewAggregates(
+	provider store.Provider,
+	clock core.Clock,
+	claimsProvider core.ClaimsProvider,
+) application.AggregatesRepository {
+	return &aggregates{provider, clock, claimsProvider}
+}
+func aggregatesRef(client *firestore.Client, claimsProvider core.ClaimsProvider) *firestore.CollectionRef {
+	userID := claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("aggregates")
+}
+func aggregateKey(month *time.Time) string {
+	return month.Format("2006-01-02")
+}
+func (t *aggregates) GetByMonth(month *time.Time) (*models.Aggregate, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	start := t.clock.GetMonthStartDay(month)
+
+	doc, err := aggregatesRef(client, t.claimsProvider).Doc(aggregateKey(&start)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAggregate(doc, t.clock)
+}
+func (t *aggregates) Rebuild(month *time.Time) (*models.Aggregate, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	start := t.clock.GetMonthStartDay(month)
+	end := t.clock.AddMonths(&start, 1)
+	ref := aggregatesRef(client, t.claimsProvider).Doc(aggregateKey(&start))
+	transactionsRef := client.Collection("users").Doc(*t.claimsProvider.GetUserID()).Collection("transactions")
+
+	var aggregate *models.Aggregate
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		transactions, err := getTransactionsInTx(tx, transactionsRef, &start, &end, t.clock)
+		if err != nil {
+			return err
+		}
+		aggregate = accountbook.BuildAggregate(start, transactions, t.clock.GetDay)
+		aggregate.UpdatedAt = t.clock.Now()
+		return tx.Set(ref, aggregate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregate.AggregateID = ref.ID
+	return aggregate, nil
+}
+func toAggregate(doc *firestore.DocumentSnapshot, clock core.Clock) (*models.Aggregate, error) {
+	var aggregate models.Aggregate
+	if err := doc.DataTo(&aggregate); err != nil {
+		return nil, err
+	}
+	aggregate.AggregateID = doc.Ref.ID
+	aggregate.Month = aggregate.Month.In(clock.DefaultLocation())
+	for i := range aggregate.Daily {
+		aggregate.Daily[i].Date = aggregate.Daily[i].Date.In(clock.DefaultLocation())
+	}
+	return &aggregate, nil
+}
+func getTransactionsInTx(
+	tx *firestore.Transaction,
+	transactionsRef *firestore.CollectionRef,
+	start *time.Time,
+	end *time.Time,
+	clock core.Clock,
+) ([]models.Transaction, error) {
+	transactions := make([]models.Transaction, 0)
+	iter := tx.Documents(transactionsRef.Where("date", ">=", *start).Where("date", "<", *end))
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var transaction models.Transaction
+		if err := doc.DataTo(&transaction); err != nil {
+			return nil, err
+		}
+		transaction.TransactionID = doc.Ref.ID
+		transaction.Date = transaction.Date.In(clock.DefaultLocation())
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func newAggregateUpdater(
+	client *firestore.Client,
+	tx *firestore.Transaction,
+	claimsProvider core.ClaimsProvider,
+	transactionsRef *firestore.CollectionRef,
+	clock core.Clock,
+) *aggregateUpdater {
+	return &aggregateUpdater{
+		tx:              tx,
+		aggregatesRef:   aggregatesRef(client, claimsProvider),
+		transactionsRef: transactionsRef,
+		clock:           clock,
+		aggregates:      make(map[string]*models.Aggregate),
+	}
+}
+
+// apply は取引の金額を取引の月の集計に加えます
+// 集計がまだ無い月は、その月の取引から作成してから加えます
+func (t *aggregateUpdater) apply(transaction *models.Transaction, sign int) error {
+	month := t.clock.GetMonthStartDay(&transaction.Date)
+	key := aggregateKey(&month)
+	aggregate, ok := t.aggregates[key]
+	if !ok {
+		doc, err := t.tx.Get(t.aggregatesRef.Doc(key))
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			aggregate, err = toAggregate(doc, t.clock)
+			if err != nil {
+				return err
+			}
+		} else {
+			end := t.clock.AddMonths(&month, 1)
+			transactions, err := getTransactionsInTx(t.tx, t.transactionsRef, &month, &end, t.clock)
+			if err != nil {
+				return err
+			}
+			aggregate = accountbook.BuildAggregate(month, transactions, t.clock.GetDay)
+		}
+		t.aggregates[key] = aggregate
+	}
+	accountbook.ApplyToAggregate(aggregate, transaction, t.clock.GetDay(&transaction.Date), sign)
+	return nil
+}
+
+// write は更新した集計を書き込みます
+func (t *aggregateUpdater) write() error {
+	now := t.clock.Now()
+	for key, aggregate := range t.aggregates {
+		aggregate.UpdatedAt = now
+		if err := t.tx.Set(t.aggregatesRef.Doc(key), aggregate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repos
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	budgets struct {
+		provider       store.Provider
+		clock          core.Clock
+		claimsProvider core.ClaimsProvider
+	}
+)
+
+// NewBudgets はインスタンスを生成します
+func NewBudgets(
+	provider store.Provider,
+	clock core.Clock,
+	claimsProvider core.ClaimsProvider,
+) application.BudgetsRepository {
+	return &budgets{provider, clock, claimsProvider}
+}
+func (t *budgets) budgetsRef(client *firestore.Client) *firestore.CollectionRef {
+	userID := t.claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("budgets")
+}
+func (t *budgets) Get() (*[]models.Budget, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	budgets := make([]models.Budget, 0)
+	iter := t.budgetsRef(client).OrderBy("month", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var budget models.Budget
+		if err := doc.DataTo(&budget); err != nil {
+			return nil, err
+		}
+		budget.BudgetID = doc.Ref.ID
+		budget.Month = budget.Month.In(t.clock.DefaultLocation())
+		budgets = append(budgets, budget)
+	}
+	return &budgets, nil
+}
+func (t *budgets) GetByID(id *string) (*models.Budget, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	doc, err := t.budgetsRef(client).Doc(*id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, core.NewError(application.NotFound)
+		}
+		return nil, err
+	}
+	var budget models.Budget
+	if err := doc.DataTo(&budget); err != nil {
+		return nil, err
+	}
+	budget.BudgetID = *id
+	budget.Month = budget.Month.In(t.clock.DefaultLocation())
+	return &budget, nil
+}
+func (t *budgets) Create(model *models.Budget) (*string, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	model.CreatedAt = t.clock.Now()
+	ref, _, err := t.budgetsRef(client).Add(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return &ref.ID, nil
+}
+func (t *budgets) Update(id *string, model *models.Budget) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.budgetsRef(client).Doc(*id).Set(ctx, model)
+	return err
+}
+func (t *budgets) Delete(id *string) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.budgetsRef(client).Doc(*id).Delete(ctx)
+	return err
+}
@@ -0,0 +1,98 @@
+package repos
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	categorizationRules struct {
+		provider       store.Provider
+		clock          core.Clock
+		claimsProvider core.ClaimsProvider
+	}
+)
+
+// NewCategorizationRules はインスタンスを生成します
+func NewCategorizationRules(
+	provider store.Provider,
+	clock core.Clock,
+	claimsProvider core.ClaimsProvider,
+) application.CategorizationRulesRepository {
+	return &categorizationRules{provider, clock, claimsProvider}
+}
+func (t *categorizationRules) rulesRef(client *firestore.Client) *firestore.CollectionRef {
+	userID := t.claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("categorizationRules")
+}
+func (t *categorizationRules) Get() (*[]models.CategorizationRule, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	rules := make([]models.CategorizationRule, 0)
+	iter := t.rulesRef(client).OrderBy("priority", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rule models.CategorizationRule
+		if err := doc.DataTo(&rule); err != nil {
+			return nil, err
+		}
+		rule.RuleID = doc.Ref.ID
+		rules = append(rules, rule)
+	}
+	return &rules, nil
+}
+func (t *categorizationRules) GetByID(id *string) (*models.CategorizationRule, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	doc, err := t.rulesRef(client).Doc(*id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, core.NewError(application.NotFound)
+		}
+		return nil, err
+	}
+	var rule models.CategorizationRule
+	if err := doc.DataTo(&rule); err != nil {
+		return nil, err
+	}
+	rule.RuleID = *id
+	return &rule, nil
+}
+func (t *categorizationRules) Create(model *models.CategorizationRule) (*string, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	model.CreatedAt = t.clock.Now()
+	ref, _, err := t.rulesRef(client).Add(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return &ref.ID, nil
+}
+func (t *categorizationRules) Update(id *string, model *models.CategorizationRule) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.rulesRef(client).Doc(*id).Set(ctx, model)
+	return err
+}
+func (t *categorizationRules) Delete(id *string) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.rulesRef(client).Doc(*id).Delete(ctx)
+	return err
+}
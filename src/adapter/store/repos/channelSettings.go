@@ -0,0 +1,65 @@
+package repos
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	channelSettings struct {
+		provider       store.Provider
+		claimsProvider core.ClaimsProvider
+	}
+	channelSettingsEntity struct {
+		WebhookURL    string `firestore:"webhookUrl"`
+		WebhookSecret string `firestore:"webhookSecret"`
+	}
+)
+
+// NewChannelSettings はインスタンスを生成します
+func NewChannelSettings(
+	provider store.Provider,
+	claimsProvider core.ClaimsProvider,
+) notifications.ChannelSettingsRepository {
+	return &channelSettings{provider, claimsProvider}
+}
+func (t *channelSettings) channelSettingsRef(client *firestore.Client) *firestore.DocumentRef {
+	userID := t.claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("notificationSettings").Doc("channels")
+}
+
+// Get は配信先の設定を取得します。未設定の場合は空の設定を返します
+func (t *channelSettings) Get() notifications.ChannelSettings {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	doc, err := t.channelSettingsRef(client).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notifications.NewChannelSettings("", "")
+		}
+		panic(err)
+	}
+	var entity channelSettingsEntity
+	if err := doc.DataTo(&entity); err != nil {
+		panic(err)
+	}
+	return notifications.NewChannelSettings(entity.WebhookURL, entity.WebhookSecret)
+}
+func (t *channelSettings) Save(channelSettings notifications.ChannelSettings) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.channelSettingsRef(client).Set(ctx, &channelSettingsEntity{
+		WebhookURL:    channelSettings.GetWebhookURL(),
+		WebhookSecret: channelSettings.GetWebhookSecret(),
+	})
+	if err != nil {
+		panic(err)
+	}
+}
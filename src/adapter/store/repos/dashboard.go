@@ -11,6 +11,7 @@ import (
 	"github.com/wakuwaku3/account-book.api/src/adapter/store"
 	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 	"google.golang.org/api/iterator"
 )
@@ -43,6 +44,14 @@ func (t *dashboard) dailyRef(client *firestore.Client, dashboardID *string) *fir
 	return t.dashboardsRef(client).Doc(*dashboardID).Collection("daily")
 }
 
+func (t *dashboard) categoriesRef(client *firestore.Client, dashboardID *string) *firestore.CollectionRef {
+	return t.dashboardsRef(client).Doc(*dashboardID).Collection("categories")
+}
+
+func (t *dashboard) adjustmentsRef(client *firestore.Client, dashboardID *string) *firestore.CollectionRef {
+	return t.dashboardsRef(client).Doc(*dashboardID).Collection("adjustments")
+}
+
 func (t *dashboard) GetByID(id *string) (*models.Dashboard, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
@@ -63,6 +72,11 @@ func (t *dashboard) GetByID(id *string) (*models.Dashboard, error) {
 		return nil, err
 	}
 	model.Actual = *actual
+	adjustments, err := t.getAdjustments(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Adjustments = *adjustments
 	return &model, nil
 }
 func (t *dashboard) ExistsClosedNext(id *string) error {
@@ -105,6 +119,11 @@ func (t *dashboard) GetLatestClosedDashboard() (*models.Dashboard, error) {
 		return nil, err
 	}
 	model.Actual = *actual
+	adjustments, err := t.getAdjustments(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Adjustments = *adjustments
 	return &model, nil
 }
 func (t *dashboard) getActual(
@@ -131,6 +150,30 @@ func (t *dashboard) getActual(
 	}
 	return &slice, nil
 }
+func (t *dashboard) getAdjustments(
+	ctx context.Context,
+	client *firestore.Client,
+	dashboardID string,
+) (*[]models.Adjustment, error) {
+	iter := t.adjustmentsRef(client, &dashboardID).OrderBy("createdAt", firestore.Asc).Documents(ctx)
+	slice := make([]models.Adjustment, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var model models.Adjustment
+		if err := doc.DataTo(&model); err != nil {
+			return nil, err
+		}
+		model.AdjustmentID = doc.Ref.ID
+		slice = append(slice, model)
+	}
+	return &slice, nil
+}
 func (t *dashboard) getDaily(
 	ctx context.Context,
 	client *firestore.Client,
@@ -151,6 +194,31 @@ func (t *dashboard) getDaily(
 			return nil, err
 		}
 		model.DailyID = doc.Ref.ID
+		model.Date = model.Date.In(t.clock.DefaultLocation())
+		slice = append(slice, model)
+	}
+	return &slice, nil
+}
+func (t *dashboard) getCategories(
+	ctx context.Context,
+	client *firestore.Client,
+	dashboardID string,
+) (*[]models.CategorySummary, error) {
+	iter := t.categoriesRef(client, &dashboardID).OrderBy("amount", firestore.Desc).Documents(ctx)
+	slice := make([]models.CategorySummary, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var model models.CategorySummary
+		if err := doc.DataTo(&model); err != nil {
+			return nil, err
+		}
+		model.CategorySummaryID = doc.Ref.ID
 		slice = append(slice, model)
 	}
 	return &slice, nil
@@ -180,13 +248,52 @@ func (t *dashboard) GetOldestOpenDashboard() (*models.Dashboard, error) {
 		return nil, err
 	}
 	model.Actual = *actual
+	adjustments, err := t.getAdjustments(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Adjustments = *adjustments
 	daily, err := t.getDaily(ctx, client, model.DashboardID)
 	if err != nil {
 		return nil, err
 	}
 	model.Daily = *daily
+	categories, err := t.getCategories(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Categories = *categories
 	return &model, nil
 }
+func (t *dashboard) GetClosedDashboards() (*[]models.Dashboard, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	dashboards := make([]models.Dashboard, 0)
+	iter := t.dashboardsRef(client).Where("state", "==", "closed").OrderBy("date", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var model models.Dashboard
+		if err := doc.DataTo(&model); err != nil {
+			return nil, err
+		}
+		model.Date = model.Date.In(t.clock.DefaultLocation())
+		model.DashboardID = doc.Ref.ID
+		dashboards = append(dashboards, model)
+	}
+	return &dashboards, nil
+}
+func (t *dashboard) GetDaily(dashboardID *string) (*[]models.Daily, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	return t.getDaily(ctx, client, *dashboardID)
+}
 func (t *dashboard) GetByMonth(month *time.Time) (*models.Dashboard, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
@@ -211,11 +318,21 @@ func (t *dashboard) GetByMonth(month *time.Time) (*models.Dashboard, error) {
 		return nil, err
 	}
 	model.Actual = *actual
+	adjustments, err := t.getAdjustments(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Adjustments = *adjustments
 	daily, err := t.getDaily(ctx, client, model.DashboardID)
 	if err != nil {
 		return nil, err
 	}
 	model.Daily = *daily
+	categories, err := t.getCategories(ctx, client, model.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	model.Categories = *categories
 	return &model, nil
 }
 func (t *dashboard) Create(month *time.Time) (*string, error) {
@@ -226,7 +343,7 @@ func (t *dashboard) Create(month *time.Time) (*string, error) {
 	doc, err := iter.Next()
 
 	start := t.clock.GetMonthStartDay(month)
-	closedDate := start.AddDate(0, -1, 0)
+	closedDate := t.clock.AddMonths(&start, -1)
 	if err != iterator.Done {
 		if err != nil {
 			return nil, err
@@ -243,7 +360,7 @@ func (t *dashboard) Create(month *time.Time) (*string, error) {
 	log.Info(start)
 	log.Info(closedDate)
 	var id *string
-	for date := closedDate.AddDate(0, 1, 0); start.Equal(date) || start.After(date); date = date.AddDate(0, 1, 0) {
+	for date := t.clock.AddMonths(&closedDate, 1); start.Equal(date) || start.After(date); date = t.clock.AddMonths(&date, 1) {
 		log.Info(date)
 		iter2 := t.dashboardsRef(client).Where("date", "==", date).Documents(ctx)
 		doc, err := iter2.Next()
@@ -282,10 +399,48 @@ func (t *dashboard) Approve(model *models.Dashboard) error {
 		newRef := t.dailyRef(client, &model.DashboardID).NewDoc()
 		batch.Create(newRef, daily)
 	}
+	for _, category := range model.Categories {
+		newRef := t.categoriesRef(client, &model.DashboardID).NewDoc()
+		batch.Create(newRef, category)
+	}
 
 	_, err := batch.Commit(ctx)
 	return err
 }
+
+// ApproveAll は複数の月を 1 回のバッチでまとめて締めます
+// 全ての月を締めるか、いずれも締めないかのどちらかになるよう、書き込みがバッチの上限を超える場合は何も書き込みません
+func (t *dashboard) ApproveAll(dashboards *[]models.Dashboard) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	count := 0
+	for _, model := range *dashboards {
+		count += 1 + len(model.Daily) + len(model.Categories)
+	}
+	if count > maxBatchSize {
+		return core.NewError(application.TooManyOperations)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	batch := client.Batch()
+	for _, model := range *dashboards {
+		m := model
+		batch.Set(t.dashboardsRef(client).Doc(m.DashboardID), &m)
+		for _, daily := range m.Daily {
+			newRef := t.dailyRef(client, &m.DashboardID).NewDoc()
+			batch.Create(newRef, daily)
+		}
+		for _, category := range m.Categories {
+			newRef := t.categoriesRef(client, &m.DashboardID).NewDoc()
+			batch.Create(newRef, category)
+		}
+	}
+	_, err := batch.Commit(ctx)
+	return err
+}
 func (t *dashboard) CancelApprove(model *models.Dashboard) error {
 	client := t.provider.GetClient()
 	ctx := context.Background()
@@ -294,20 +449,132 @@ func (t *dashboard) CancelApprove(model *models.Dashboard) error {
 	ref := t.dashboardsRef(client).Doc(model.DashboardID)
 	batch.Set(ref, model)
 
-	iter := t.dailyRef(client, &model.DashboardID).Documents(ctx)
+	for _, collection := range []*firestore.CollectionRef{
+		t.dailyRef(client, &model.DashboardID),
+		t.categoriesRef(client, &model.DashboardID),
+	} {
+		iter := collection.Documents(ctx)
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			batch.Delete(doc.Ref)
+		}
+	}
+
+	_, err := batch.Commit(ctx)
+	return err
+}
+
+// CancelApproveCascade は指定月とそれ以降に連続する締め済みの月を 1 回のトランザクションで取り消します
+// トランザクションで締め済みの月を読み直すため、同時に締められた月も取り消しの対象になります
+// 書き込みがトランザクションの上限を超える場合は TooManyOperations を返し、いずれの月も取り消しません
+func (t *dashboard) CancelApproveCascade(id *string, reopen func(model *models.Dashboard)) (*[]models.Dashboard, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	var dashboards []models.Dashboard
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		chain, err := t.getClosedChain(client, tx, id)
+		if err != nil {
+			return err
+		}
+		if len(chain) == 0 {
+			return errors.New("this dashboard is not closed")
+		}
+
+		// トランザクションでは全ての読み込みを書き込みより先に行う必要があります
+		refs := make([]*firestore.DocumentRef, 0)
+		for _, model := range chain {
+			for _, collection := range []*firestore.CollectionRef{
+				t.dailyRef(client, &model.DashboardID),
+				t.categoriesRef(client, &model.DashboardID),
+			} {
+				docs, err := tx.Documents(collection).GetAll()
+				if err != nil {
+					return err
+				}
+				for _, doc := range docs {
+					refs = append(refs, doc.Ref)
+				}
+			}
+		}
+		if len(chain)+len(refs) > maxBatchSize {
+			return core.NewError(application.TooManyOperations)
+		}
+
+		for i := range chain {
+			reopen(&chain[i])
+			if err := tx.Set(t.dashboardsRef(client).Doc(chain[i].DashboardID), &chain[i]); err != nil {
+				return err
+			}
+		}
+		for _, ref := range refs {
+			if err := tx.Delete(ref); err != nil {
+				return err
+			}
+		}
+		dashboards = chain
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dashboards, nil
+}
+
+// getClosedChain は指定月とそれ以降に連続する締め済みの月を古い順に取得します
+// 指定月が締められていない場合は空を返します
+func (t *dashboard) getClosedChain(client *firestore.Client, tx *firestore.Transaction, id *string) ([]models.Dashboard, error) {
+	doc, err := tx.Get(t.dashboardsRef(client).Doc(*id))
+	if err != nil {
+		return nil, err
+	}
+	current, err := t.toDashboard(doc)
+	if err != nil {
+		return nil, err
+	}
+	if current.State != "closed" {
+		return []models.Dashboard{}, nil
+	}
+	chain := []models.Dashboard{*current}
+
+	query := t.dashboardsRef(client).Where("date", ">", current.Date).OrderBy("date", firestore.Asc)
+	iter := tx.Documents(query)
+	defer iter.Stop()
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
-		batch.Delete(doc.Ref)
+		next, err := t.toDashboard(doc)
+		if err != nil {
+			return nil, err
+		}
+		month := t.clock.AddMonths(&chain[len(chain)-1].Date, 1)
+		if next.State != "closed" || !next.Date.Equal(t.clock.GetMonthStartDay(&month)) {
+			break
+		}
+		chain = append(chain, *next)
 	}
+	return chain, nil
+}
 
-	_, err := batch.Commit(ctx)
-	return err
+// toDashboard はドキュメントをダッシュボードに変換します
+func (t *dashboard) toDashboard(doc *firestore.DocumentSnapshot) (*models.Dashboard, error) {
+	var model models.Dashboard
+	if err := doc.DataTo(&model); err != nil {
+		return nil, err
+	}
+	model.Date = model.Date.In(t.clock.DefaultLocation())
+	model.DashboardID = doc.Ref.ID
+	return &model, nil
 }
 func (t *dashboard) GetActual(dashboardID *string, id *string) (*models.Actual, error) {
 	client := t.provider.GetClient()
@@ -353,10 +620,64 @@ func (t *dashboard) UpdateActual(dashboardID *string, id *string, model *models.
 	}
 	return nil
 }
-func (t *dashboard) AdjustBalance(id *string, balance int) error {
+func (t *dashboard) GetAdjustments(dashboardID *string) (*[]models.Adjustment, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
+	return t.getAdjustments(ctx, client, *dashboardID)
+}
 
-	_, err := t.dashboardsRef(client).Doc(*id).Set(ctx, map[string]interface{}{"balance": balance})
-	return err
+// AddAdjustment は残高の調整を追加し、調整後の残高を返します
+// 同時に調整しても残高が失われないよう、調整の読み込みと残高の計算・書き込みを 1 回のトランザクションで行います
+// 残高は前月残高・当月収支・調整額の合計から求めます
+func (t *dashboard) AddAdjustment(dashboardID *string, model *models.Adjustment) (*string, int, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	model.CreatedAt = t.clock.Now()
+	dashboardRef := t.dashboardsRef(client).Doc(*dashboardID)
+	ref := t.adjustmentsRef(client, dashboardID).NewDoc()
+	var balance int
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(dashboardRef)
+		if err != nil {
+			return err
+		}
+		current, err := t.toDashboard(doc)
+		if err != nil {
+			return err
+		}
+		if current.State != "closed" {
+			return errors.New("this dashboard is not closed")
+		}
+		docs, err := tx.Documents(t.adjustmentsRef(client, dashboardID)).GetAll()
+		if err != nil {
+			return err
+		}
+		adjustments := make([]models.Adjustment, 0)
+		for _, doc := range docs {
+			var adjustment models.Adjustment
+			if err := doc.DataTo(&adjustment); err != nil {
+				return err
+			}
+			adjustments = append(adjustments, adjustment)
+		}
+
+		balance = accountbook.SumAdjustments(adjustments) + model.Amount
+		if current.CurrentBalance != nil {
+			balance += *current.CurrentBalance
+		}
+		if current.PreviousBalance != nil {
+			balance += *current.PreviousBalance
+		}
+		if err := tx.Create(ref, model); err != nil {
+			return err
+		}
+		return tx.Update(dashboardRef, []firestore.Update{
+			{Path: "balance", Value: balance},
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return &ref.ID, balance, nil
 }
@@ -0,0 +1,207 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	inbox struct {
+		provider       store.Provider
+		clock          core.Clock
+		claimsProvider core.ClaimsProvider
+	}
+	inboxEntity struct {
+		Title              string     `firestore:"title"`
+		Body               string     `firestore:"body"`
+		Severity           string     `firestore:"severity"`
+		NotificationRuleID string     `firestore:"notificationRuleId"`
+		Metrics            string     `firestore:"metrics"`
+		Threshold          int        `firestore:"threshold"`
+		Value              int        `firestore:"value"`
+		Month              time.Time  `firestore:"month"`
+		CreatedAt          time.Time  `firestore:"createdAt"`
+		IsRead             bool       `firestore:"isRead"`
+		ReadAt             *time.Time `firestore:"readAt"`
+	}
+)
+
+// NewInbox はインスタンスを生成します
+func NewInbox(
+	provider store.Provider,
+	clock core.Clock,
+	claimsProvider core.ClaimsProvider,
+) notifications.InboxRepository {
+	return &inbox{provider, clock, claimsProvider}
+}
+func (t *inbox) inboxRef(client *firestore.Client) *firestore.CollectionRef {
+	userID := t.claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("notifications")
+}
+func (t *inbox) Add(notification *notifications.Notification) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, _, err := t.inboxRef(client).Add(ctx, &inboxEntity{
+		Title:              notification.Title(),
+		Body:               notification.Body(),
+		Severity:           notification.Severity(),
+		NotificationRuleID: *notification.NotificationRuleID,
+		Metrics:            notification.Metrics,
+		Threshold:          notification.Threshold,
+		Value:              notification.Value,
+		Month:              notification.Month,
+		CreatedAt:          t.clock.Now(),
+	})
+	return err
+}
+func (t *inbox) Get(filter *notifications.InboxFilter) (*[]notifications.InboxNotification, *string, core.Error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	ref := t.inboxRef(client)
+
+	query := ref.OrderBy("createdAt", firestore.Desc)
+	if filter.UnreadOnly {
+		query = ref.Where("isRead", "==", false).OrderBy("createdAt", firestore.Desc)
+	}
+	if filter.Cursor != nil {
+		doc, err := ref.Doc(*filter.Cursor).Get(ctx)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, nil, core.NewError(notifications.InValidCursor)
+			}
+			panic(err)
+		}
+		query = query.StartAfter(doc)
+	}
+	// 続きがあるかを判定するため 1 件多く取得します
+	iter := query.Limit(filter.Limit + 1).Documents(ctx)
+
+	items := make([]notifications.InboxNotification, 0)
+	var next *string
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+		if len(items) == filter.Limit {
+			last := items[len(items)-1].NotificationID
+			next = &last
+			break
+		}
+		var entity inboxEntity
+		if err := doc.DataTo(&entity); err != nil {
+			panic(err)
+		}
+		items = append(items, entity.newInboxNotification(doc.Ref.ID, t.clock))
+	}
+	return &items, next, nil
+}
+func (t inboxEntity) newInboxNotification(id string, clock core.Clock) notifications.InboxNotification {
+	item := notifications.InboxNotification{
+		NotificationID:     id,
+		Title:              t.Title,
+		Body:               t.Body,
+		Severity:           t.Severity,
+		NotificationRuleID: t.NotificationRuleID,
+		CreatedAt:          t.CreatedAt.In(clock.DefaultLocation()),
+	}
+	if t.ReadAt != nil {
+		readAt := t.ReadAt.In(clock.DefaultLocation())
+		item.ReadAt = &readAt
+	}
+	return item
+}
+func (t *inbox) Read(id string) core.Error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	ref := t.inboxRef(client).Doc(id)
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var entity inboxEntity
+		if err := doc.DataTo(&entity); err != nil {
+			return err
+		}
+		// 既読の日時は最初に読んだ日時のままとします
+		if entity.IsRead {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "isRead", Value: true},
+			{Path: "readAt", Value: t.clock.Now()},
+		})
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return core.NewError(core.NotFound)
+		}
+		panic(err)
+	}
+	return nil
+}
+func (t *inbox) ReadAll() int {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	now := t.clock.Now()
+
+	iter := t.inboxRef(client).Where("isRead", "==", false).Select().Documents(ctx)
+	refs := make([]*firestore.DocumentRef, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+		refs = append(refs, doc.Ref)
+	}
+	for start := 0; start < len(refs); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(refs) {
+			end = len(refs)
+		}
+		batch := client.Batch()
+		for _, ref := range refs[start:end] {
+			batch.Update(ref, []firestore.Update{
+				{Path: "isRead", Value: true},
+				{Path: "readAt", Value: now},
+			})
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			panic(err)
+		}
+	}
+	return len(refs)
+}
+func (t *inbox) CountUnread() int {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	iter := t.inboxRef(client).Where("isRead", "==", false).Select().Documents(ctx)
+	count := 0
+	for {
+		_, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+		count++
+	}
+	return count
+}
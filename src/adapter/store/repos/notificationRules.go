@@ -2,6 +2,7 @@ package repos
 
 import (
 	"context"
+	"time"
 
 	"cloud.google.com/go/firestore"
 
@@ -22,8 +23,40 @@ type (
 		claimsProvider core.ClaimsProvider
 	}
 	notificationRuleEntity struct {
-		Metrics   string `firestore:"metrics"`
-		Threshold int    `firestore:"threshold"`
+		Metrics       string   `firestore:"metrics"`
+		Threshold     int      `firestore:"threshold"`
+		ThresholdUnit string   `firestore:"thresholdUnit"`
+		Operator      string   `firestore:"operator"`
+		Period        string   `firestore:"period"`
+		Category      *int     `firestore:"category"`
+		Channels      []string `firestore:"channels"`
+		// Rearm は未設定の場合は再通知を有効とします
+		Rearm         *bool `firestore:"rearm"`
+		CooldownHours int   `firestore:"cooldownHours"`
+		// ExceededPeriod は条件を満たした期間の開始日時です
+		// 期間を指定できる前は月の開始日時を保存していたため、同じフィールドを使います
+		ExceededPeriod  *time.Time         `firestore:"exceededMonth"`
+		LastObservation *observationEntity `firestore:"lastObservation"`
+		LastFiredAt     *time.Time         `firestore:"lastFiredAt"`
+		// PendingNotifications は配信に失敗した配信先への通知です
+		PendingNotifications []pendingNotificationEntity `firestore:"pendingNotifications"`
+		IsDeleted            bool                        `firestore:"isDeleted"`
+	}
+	observationEntity struct {
+		Period time.Time `firestore:"period"`
+		Value  int       `firestore:"value"`
+	}
+	pendingNotificationEntity struct {
+		Metrics     string    `firestore:"metrics"`
+		Operator    string    `firestore:"operator"`
+		Period      string    `firestore:"period"`
+		PeriodStart time.Time `firestore:"periodStart"`
+		Category    *int      `firestore:"category"`
+		Threshold   int       `firestore:"threshold"`
+		Value       int       `firestore:"value"`
+		Month       time.Time `firestore:"month"`
+		Channels    []string  `firestore:"channels"`
+		Attempts    int       `firestore:"attempts"`
 	}
 )
 
@@ -60,7 +93,68 @@ func (t notificationRuleEntity) newNotificationRule(id notifications.Notificatio
 	if err != nil {
 		panic(err)
 	}
-	return notifications.NewNotificationRule(id, metrics, notifications.NewThreshold(t.Threshold))
+	threshold, err := notifications.NewThreshold(t.Threshold, t.ThresholdUnit)
+	if err != nil {
+		panic(err)
+	}
+	// 配信先を保存する前に作成したルールはアプリ内の通知のみとします
+	channels, err := notifications.NewChannels(t.Channels)
+	if err != nil {
+		panic(err)
+	}
+	notificationRule := notifications.NewNotificationRule(id, metrics, threshold, channels)
+	// 比較方法と期間を保存する前に作成したルールは既定の条件のままとします
+	if t.Operator != "" {
+		operator, err := notifications.NewOperator(t.Operator)
+		if err != nil {
+			panic(err)
+		}
+		notificationRule.SetOperator(operator)
+	}
+	if t.Period != "" {
+		period, err := notifications.NewPeriod(t.Period)
+		if err != nil {
+			panic(err)
+		}
+		notificationRule.SetPeriod(period)
+	}
+	notificationRule.SetCategory(t.Category)
+	if t.Rearm != nil {
+		notificationRule.SetRearm(*t.Rearm)
+	}
+	cooldown, err := notifications.NewCooldown(t.CooldownHours)
+	if err != nil {
+		panic(err)
+	}
+	notificationRule.SetCooldown(cooldown)
+	notificationRule.SetExceededPeriod(t.ExceededPeriod)
+	notificationRule.SetLastFiredAt(t.LastFiredAt)
+	if t.LastObservation != nil {
+		notificationRule.SetLastObservation(&notifications.Observation{
+			Period: t.LastObservation.Period,
+			Value:  t.LastObservation.Value,
+		})
+	}
+	if len(t.PendingNotifications) > 0 {
+		pending := make([]notifications.Notification, len(t.PendingNotifications))
+		for i, x := range t.PendingNotifications {
+			pending[i] = notifications.Notification{
+				NotificationRuleID: id,
+				Metrics:            x.Metrics,
+				Operator:           x.Operator,
+				Period:             x.Period,
+				PeriodStart:        x.PeriodStart,
+				Category:           x.Category,
+				Threshold:          x.Threshold,
+				Value:              x.Value,
+				Month:              x.Month,
+				Channels:           x.Channels,
+				Attempts:           x.Attempts,
+			}
+		}
+		notificationRule.SetPendingNotifications(pending)
+	}
+	return notificationRule
 }
 func (t *notificationRules) Get() *[]notifications.NotificationRule {
 	client := t.provider.GetClient()
@@ -84,35 +178,100 @@ func (t *notificationRules) Get() *[]notifications.NotificationRule {
 	}
 	return &notificationRules
 }
-func (t *notificationRules) New(metrics notifications.Metrics, threshold notifications.Threshold) notifications.NotificationRule {
+func (t *notificationRules) New(notificationRule notifications.NotificationRule) notifications.NotificationRule {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	entity := newNotificationRuleEntity(metrics, threshold)
+	entity := newNotificationRuleEntity(notificationRule)
 	ref, _, err := t.notificationRulesRef(client).Add(ctx, entity)
 	if err != nil {
 		panic(err)
 	}
-	return notifications.NewNotificationRule(notifications.NotificationRuleID(&ref.ID), metrics, threshold)
+	return entity.newNotificationRule(notifications.NotificationRuleID(&ref.ID))
 }
 func (t *notificationRules) Save(notificationRule notifications.NotificationRule) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	entity := newNotificationRuleEntity(notificationRule.GetMetrics(), notificationRule.GetThreshold())
+	entity := newNotificationRuleEntity(notificationRule)
 	_, err := t.notificationRulesRef(client).Doc(*notificationRule.GetID()).Set(ctx, entity)
 	if err != nil {
 		panic(err)
 	}
 }
-func newNotificationRuleEntity(metrics notifications.Metrics, threshold notifications.Threshold) *notificationRuleEntity {
-	return &notificationRuleEntity{
-		Metrics:   metrics.Get(),
-		Threshold: threshold.Get(),
+func (t *notificationRules) Modify(
+	id notifications.NotificationRuleID,
+	modify func(notificationRule notifications.NotificationRule) bool,
+) core.Error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	ref := t.notificationRulesRef(client).Doc(*id)
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var entity notificationRuleEntity
+		if err := doc.DataTo(&entity); err != nil {
+			return err
+		}
+		notificationRule := entity.newNotificationRule(id)
+		if !modify(notificationRule) {
+			return nil
+		}
+		return tx.Set(ref, newNotificationRuleEntity(notificationRule))
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return core.NewError(core.NotFound)
+		}
+		panic(err)
+	}
+	return nil
+}
+func newNotificationRuleEntity(notificationRule notifications.NotificationRule) *notificationRuleEntity {
+	channels := make([]string, len(notificationRule.GetChannels()))
+	for i, channel := range notificationRule.GetChannels() {
+		channels[i] = channel.Get()
+	}
+	rearm := notificationRule.GetRearm()
+	entity := &notificationRuleEntity{
+		Metrics:        notificationRule.GetMetrics().Get(),
+		Threshold:      notificationRule.GetThreshold().Get(),
+		ThresholdUnit:  notificationRule.GetThreshold().GetUnit(),
+		Operator:       notificationRule.GetOperator().Get(),
+		Period:         notificationRule.GetPeriod().Get(),
+		Category:       notificationRule.GetCategory(),
+		Channels:       channels,
+		Rearm:          &rearm,
+		CooldownHours:  notificationRule.GetCooldown().Get(),
+		ExceededPeriod: notificationRule.GetExceededPeriod(),
+		LastFiredAt:    notificationRule.GetLastFiredAt(),
+	}
+	if observation := notificationRule.GetLastObservation(); observation != nil {
+		entity.LastObservation = &observationEntity{
+			Period: observation.Period,
+			Value:  observation.Value,
+		}
+	}
+	for _, x := range notificationRule.GetPendingNotifications() {
+		entity.PendingNotifications = append(entity.PendingNotifications, pendingNotificationEntity{
+			Metrics:     x.Metrics,
+			Operator:    x.Operator,
+			Period:      x.Period,
+			PeriodStart: x.PeriodStart,
+			Category:    x.Category,
+			Threshold:   x.Threshold,
+			Value:       x.Value,
+			Month:       x.Month,
+			Channels:    x.Channels,
+			Attempts:    x.Attempts,
+		})
 	}
+	return entity
 }
 func (t *notificationRules) Delete(id notifications.NotificationRuleID) core.Error {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	_, err := t.notificationRulesRef(client).Doc(*id).Delete(ctx)
+	_, err := t.notificationRulesRef(client).Doc(*id).Delete(ctx, firestore.Exists)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return core.NewError(core.NotFound)
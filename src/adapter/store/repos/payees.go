@@ -0,0 +1,98 @@
+package repos
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/store"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	payees struct {
+		provider       store.Provider
+		clock          core.Clock
+		claimsProvider core.ClaimsProvider
+	}
+)
+
+// NewPayees はインスタンスを生成します
+func NewPayees(
+	provider store.Provider,
+	clock core.Clock,
+	claimsProvider core.ClaimsProvider,
+) application.PayeesRepository {
+	return &payees{provider, clock, claimsProvider}
+}
+func (t *payees) payeesRef(client *firestore.Client) *firestore.CollectionRef {
+	userID := t.claimsProvider.GetUserID()
+	return client.Collection("users").Doc(*userID).Collection("payees")
+}
+func (t *payees) Get() (*[]models.Payee, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	payees := make([]models.Payee, 0)
+	iter := t.payeesRef(client).OrderBy("payeeName", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var payee models.Payee
+		if err := doc.DataTo(&payee); err != nil {
+			return nil, err
+		}
+		payee.PayeeID = doc.Ref.ID
+		payees = append(payees, payee)
+	}
+	return &payees, nil
+}
+func (t *payees) GetByID(id *string) (*models.Payee, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	doc, err := t.payeesRef(client).Doc(*id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, core.NewError(application.NotFound)
+		}
+		return nil, err
+	}
+	var payee models.Payee
+	if err := doc.DataTo(&payee); err != nil {
+		return nil, err
+	}
+	payee.PayeeID = *id
+	return &payee, nil
+}
+func (t *payees) Create(model *models.Payee) (*string, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	model.CreatedAt = t.clock.Now()
+	ref, _, err := t.payeesRef(client).Add(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return &ref.ID, nil
+}
+func (t *payees) Update(id *string, model *models.Payee) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.payeesRef(client).Doc(*id).Set(ctx, model)
+	return err
+}
+func (t *payees) Delete(id *string) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.payeesRef(client).Doc(*id).Delete(ctx)
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"cloud.google.com/go/firestore"
 
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 	"google.golang.org/api/iterator"
@@ -47,14 +48,23 @@ func (t *plans) GetByID(id *string) (*models.Plan, error) {
 		return nil, err
 	}
 	plan.PlanID = *id
+	t.localize(&plan)
+	accountbook.ApplyRevision(&plan, t.clock.Now())
 	return &plan, nil
 }
 func (t *plans) Get() (*[]models.Plan, error) {
 	client := t.provider.GetClient()
+	return t.get(t.plansRef(client).Where("isDeleted", "==", false))
+}
+func (t *plans) GetIncludeDeleted() (*[]models.Plan, error) {
+	client := t.provider.GetClient()
+	return t.get(t.plansRef(client).Query)
+}
+func (t *plans) get(query firestore.Query) (*[]models.Plan, error) {
 	ctx := context.Background()
 
 	plans := make([]models.Plan, 0)
-	iter := t.plansRef(client).Where("isDeleted", "==", false).Documents(ctx)
+	iter := query.Documents(ctx)
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
@@ -68,15 +78,43 @@ func (t *plans) Get() (*[]models.Plan, error) {
 			return nil, err
 		}
 		plan.PlanID = doc.Ref.ID
+		t.localize(&plan)
+		accountbook.ApplyRevision(&plan, t.clock.Now())
 		plans = append(plans, plan)
 	}
 	return &plans, nil
 }
+
+// localize は計画の日時を時計のタイムゾーンに変換します
+// 月単位の繰り返しは日付で判定するため、Firestore から読み込んだ UTC のままでは月や日がずれます
+func (t *plans) localize(plan *models.Plan) {
+	location := t.clock.DefaultLocation()
+	plan.CreatedAt = plan.CreatedAt.In(location)
+	if plan.Start != nil {
+		start := plan.Start.In(location)
+		plan.Start = &start
+	}
+	if plan.End != nil {
+		end := plan.End.In(location)
+		plan.End = &end
+	}
+	if plan.DeletedAt != nil {
+		deletedAt := plan.DeletedAt.In(location)
+		plan.DeletedAt = &deletedAt
+	}
+	if plan.Recurrence != nil && plan.Recurrence.Until != nil {
+		until := plan.Recurrence.Until.In(location)
+		plan.Recurrence.Until = &until
+	}
+	for i := range plan.Revisions {
+		plan.Revisions[i].EffectiveFrom = plan.Revisions[i].EffectiveFrom.In(location)
+	}
+}
 func (t *plans) GetByMonth(month *time.Time) (*[]models.Plan, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
 	start := t.clock.GetMonthStartDay(month)
-	next := start.AddDate(0, 1, 0)
+	next := t.clock.AddMonths(&start, 1)
 
 	plans := make([]models.Plan, 0)
 	iter := t.plansRef(client).Where("isDeleted", "==", false).OrderBy("createdAt", firestore.Asc).Documents(ctx)
@@ -93,17 +131,10 @@ func (t *plans) GetByMonth(month *time.Time) (*[]models.Plan, error) {
 			return nil, err
 		}
 		plan.PlanID = doc.Ref.ID
-		if (plan.Start == nil || plan.Start.Before(next)) && (plan.End == nil || plan.End.After(start)) {
-			st := plan.CreatedAt
-			if plan.Start != nil {
-				st = *plan.Start
-			}
-			dif := (start.Year()-st.Year())*12 + int(start.Month()) - int(st.Month())
-			if dif%plan.Interval == 0 {
-				plans = append(plans, plan)
-			}
-		}
+		t.localize(&plan)
+		plans = append(plans, plan)
 	}
+	plans = accountbook.PlansInMonth(plans, start, next)
 	return &plans, nil
 }
 func (t *plans) Create(model *models.Plan) (*string, error) {
@@ -10,6 +10,8 @@ import (
 
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/adapter/store"
@@ -23,6 +25,9 @@ type (
 	}
 )
 
+// maxBatchSize は Firestore の1バッチで書き込める上限です
+const maxBatchSize = 500
+
 // NewTransactions はインスタンスを生成します
 func NewTransactions(
 	provider store.Provider,
@@ -40,22 +45,29 @@ func (t *transactions) Get(id *string) (*models.Transaction, error) {
 	ctx := context.Background()
 	doc, err := t.transactionsRef(client).Doc(*id).Get(ctx)
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, core.NewError(application.NotFound)
+		}
 		return nil, err
 	}
 	var transaction models.Transaction
 	if err := doc.DataTo(&transaction); err != nil {
 		return nil, err
 	}
+	transaction.Date = transaction.Date.In(t.clock.DefaultLocation())
 	return &transaction, nil
 }
 func (t *transactions) GetByMonth(month *time.Time) (*[]models.Transaction, error) {
+	start := t.clock.GetMonthStartDay(month)
+	end := t.clock.AddMonths(&start, 1)
+	return t.GetByDateRange(&start, &end)
+}
+func (t *transactions) GetByDateRange(start *time.Time, end *time.Time) (*[]models.Transaction, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	start := t.clock.GetMonthStartDay(month)
-	end := start.AddDate(0, 1, 0)
 
 	transactions := make([]models.Transaction, 0)
-	iter := t.transactionsRef(client).Where("date", ">=", start).Where("date", "<", end).OrderBy("date", firestore.Desc).Documents(ctx)
+	iter := t.transactionsRef(client).Where("date", ">=", *start).Where("date", "<", *end).OrderBy("date", firestore.Desc).Documents(ctx)
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
@@ -69,14 +81,45 @@ func (t *transactions) GetByMonth(month *time.Time) (*[]models.Transaction, erro
 			return nil, err
 		}
 		transaction.TransactionID = doc.Ref.ID
+		transaction.Date = transaction.Date.In(t.clock.DefaultLocation())
 		transactions = append(transactions, transaction)
 	}
 	return &transactions, nil
 }
+func (t *transactions) newAggregateUpdater(client *firestore.Client, tx *firestore.Transaction) *aggregateUpdater {
+	return newAggregateUpdater(client, tx, t.claimsProvider, t.transactionsRef(client), t.clock)
+}
+
+// getInTx はトランザクション内で取引を取得します。存在しない場合は nil を返します
+func (t *transactions) getInTx(tx *firestore.Transaction, ref *firestore.DocumentRef) (*models.Transaction, error) {
+	doc, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var transaction models.Transaction
+	if err := doc.DataTo(&transaction); err != nil {
+		return nil, err
+	}
+	transaction.Date = transaction.Date.In(t.clock.DefaultLocation())
+	return &transaction, nil
+}
 func (t *transactions) Create(model *models.Transaction) (*string, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	ref, _, err := t.transactionsRef(client).Add(ctx, model)
+	ref := t.transactionsRef(client).NewDoc()
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		updater := t.newAggregateUpdater(client, tx)
+		if err := updater.apply(model, 1); err != nil {
+			return err
+		}
+		if err := tx.Create(ref, model); err != nil {
+			return err
+		}
+		return updater.write()
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -85,18 +128,174 @@ func (t *transactions) Create(model *models.Transaction) (*string, error) {
 func (t *transactions) Update(id *string, model *models.Transaction) error {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	_, err := t.transactionsRef(client).Doc(*id).Set(ctx, model)
+	ref := t.transactionsRef(client).Doc(*id)
+	return client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		updater := t.newAggregateUpdater(client, tx)
+		old, err := t.getInTx(tx, ref)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			if err := updater.apply(old, -1); err != nil {
+				return err
+			}
+		}
+		if err := updater.apply(model, 1); err != nil {
+			return err
+		}
+		if err := tx.Set(ref, model); err != nil {
+			return err
+		}
+		return updater.write()
+	})
+}
+func (t *transactions) Delete(id *string) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	ref := t.transactionsRef(client).Doc(*id)
+	return client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		updater := t.newAggregateUpdater(client, tx)
+		old, err := t.getInTx(tx, ref)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return nil
+		}
+		if err := updater.apply(old, -1); err != nil {
+			return err
+		}
+		if err := tx.Delete(ref); err != nil {
+			return err
+		}
+		return updater.write()
+	})
+}
+func (t *transactions) Batch(items *[]application.TransactionsBatchItem) (*[]string, error) {
+	// 途中まで書き込まれた状態を残さないよう全件を 1 回の書き込みで反映します
+	// 月毎の集計の書き込みは多くても取引の件数と同じため、取引は上限の半分までとします
+	if len(*items) > application.MaxTransactionsBatchSize {
+		return nil, core.NewError(application.TooManyOperations)
+	}
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	ref := t.transactionsRef(client)
+
+	ids := make([]string, len(*items))
+	exists := make(map[string]bool)
+	for i, item := range *items {
+		if item.Operation == application.BatchCreate {
+			ids[i] = ref.NewDoc().ID
+			continue
+		}
+		// 同じ取引を複数回反映すると月毎の集計から二重に差し引かれます
+		if exists[*item.TransactionID] {
+			return nil, core.NewError(application.DuplicateTransactionID)
+		}
+		exists[*item.TransactionID] = true
+		ids[i] = *item.TransactionID
+	}
+	err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		updater := t.newAggregateUpdater(client, tx)
+		olds := make([]*models.Transaction, len(*items))
+		for i, item := range *items {
+			if item.Operation == application.BatchCreate {
+				continue
+			}
+			old, err := t.getInTx(tx, ref.Doc(ids[i]))
+			if err != nil {
+				return err
+			}
+			olds[i] = old
+		}
+		for i, item := range *items {
+			if old := olds[i]; old != nil {
+				if err := updater.apply(old, -1); err != nil {
+					return err
+				}
+			}
+			if item.Operation != application.BatchDelete {
+				if err := updater.apply(item.Model, 1); err != nil {
+					return err
+				}
+			}
+		}
+		for i, item := range *items {
+			var err error
+			switch item.Operation {
+			case application.BatchCreate:
+				err = tx.Create(ref.Doc(ids[i]), item.Model)
+			case application.BatchUpdate:
+				err = tx.Set(ref.Doc(ids[i]), item.Model)
+			case application.BatchDelete:
+				err = tx.Delete(ref.Doc(ids[i]))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return updater.write()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ids, nil
+}
+func (t *transactions) attachmentsRef(client *firestore.Client, transactionID *string) *firestore.CollectionRef {
+	return t.transactionsRef(client).Doc(*transactionID).Collection("attachments")
+}
+func (t *transactions) GetAttachments(transactionID *string) (*[]models.Attachment, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+
+	attachments := make([]models.Attachment, 0)
+	iter := t.attachmentsRef(client, transactionID).OrderBy("createdAt", firestore.Asc).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var attachment models.Attachment
+		if err := doc.DataTo(&attachment); err != nil {
+			return nil, err
+		}
+		attachment.AttachmentID = doc.Ref.ID
+		attachments = append(attachments, attachment)
+	}
+	return &attachments, nil
+}
+func (t *transactions) GetAttachment(transactionID *string, id *string) (*models.Attachment, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	doc, err := t.attachmentsRef(client, transactionID).Doc(*id).Get(ctx)
 	if err != nil {
-		return err
+		if status.Code(err) == codes.NotFound {
+			return nil, core.NewError(application.NotFound)
+		}
+		return nil, err
+	}
+	var attachment models.Attachment
+	if err := doc.DataTo(&attachment); err != nil {
+		return nil, err
 	}
-	return nil
+	attachment.AttachmentID = doc.Ref.ID
+	return &attachment, nil
 }
-func (t *transactions) Delete(id *string) error {
+func (t *transactions) CreateAttachment(transactionID *string, model *models.Attachment) (*string, error) {
 	client := t.provider.GetClient()
 	ctx := context.Background()
-	_, err := t.transactionsRef(client).Doc(*id).Delete(ctx)
+	ref, _, err := t.attachmentsRef(client, transactionID).Add(ctx, model)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return &ref.ID, nil
+}
+func (t *transactions) DeleteAttachment(transactionID *string, id *string) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := t.attachmentsRef(client, transactionID).Doc(*id).Delete(ctx)
+	return err
 }
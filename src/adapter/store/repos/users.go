@@ -2,6 +2,10 @@ package repos
 
 import (
 	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
 
 	"github.com/wakuwaku3/account-book.api/src/adapter/store"
 	"github.com/wakuwaku3/account-book.api/src/application"
@@ -33,3 +37,139 @@ func (t *users) Get(userID *string) (*models.User, error) {
 	doc.DataTo(&model)
 	return &model, nil
 }
+
+// ModifyMonthBoundary は月の区切りに関わるユーザーとダッシュボードを読み込んでから書き込むまでを 1 つのトランザクションで行います
+// modify で変更したユーザーの月の区切りと実績を入力中の月の日付を書き込み、ClearAggregates の場合は月毎の集計を削除します
+func (t *users) ModifyMonthBoundary(modify func(boundary *models.MonthBoundary)) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	userRef := client.Collection("users").Doc(*t.claimsProvider.GetUserID())
+	dashboardsRef := userRef.Collection("dashboards")
+	return client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var boundary models.MonthBoundary
+		doc, err := tx.Get(userRef)
+		if err != nil {
+			return err
+		}
+		if err := doc.DataTo(&boundary.User); err != nil {
+			return err
+		}
+		closed, err := tx.Documents(dashboardsRef.Where("state", "==", "closed").OrderBy("date", firestore.Desc).Limit(1)).GetAll()
+		if err != nil {
+			return err
+		}
+		for _, doc := range closed {
+			var model models.Dashboard
+			if err := doc.DataTo(&model); err != nil {
+				return err
+			}
+			model.DashboardID = doc.Ref.ID
+			boundary.LatestClosed = &model
+		}
+		opened, err := tx.Documents(dashboardsRef.Where("state", "==", "open").OrderBy("date", firestore.Asc)).GetAll()
+		if err != nil {
+			return err
+		}
+		boundary.Open = make([]models.Dashboard, len(opened))
+		dates := make([]time.Time, len(opened))
+		for i, doc := range opened {
+			if err := doc.DataTo(&boundary.Open[i]); err != nil {
+				return err
+			}
+			boundary.Open[i].DashboardID = doc.Ref.ID
+			dates[i] = boundary.Open[i].Date
+		}
+		aggregates, err := tx.Documents(aggregatesRef(client, t.claimsProvider)).GetAll()
+		if err != nil {
+			return err
+		}
+
+		modify(&boundary)
+
+		moved := make([]int, 0, len(opened))
+		for i := range boundary.Open {
+			if !boundary.Open[i].Date.Equal(dates[i]) {
+				moved = append(moved, i)
+			}
+		}
+		count := 1 + len(moved)
+		if boundary.ClearAggregates {
+			count += len(aggregates)
+		}
+		if count > maxBatchSize {
+			return core.NewError(application.TooManyOperations)
+		}
+
+		if err := tx.Update(userRef, []firestore.Update{
+			{Path: "monthStartDay", Value: boundary.User.MonthStartDay},
+			{Path: "timeZone", Value: boundary.User.TimeZone},
+			{Path: "monthSettingsHistory", Value: boundary.User.MonthSettingsHistory},
+		}); err != nil {
+			return err
+		}
+		for _, i := range moved {
+			if err := tx.Update(opened[i].Ref, []firestore.Update{{Path: "date", Value: boundary.Open[i].Date}}); err != nil {
+				return err
+			}
+		}
+		if boundary.ClearAggregates {
+			for _, doc := range aggregates {
+				if err := tx.Delete(doc.Ref); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+func (t *users) UpdateDigestSettings(model *models.User) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := client.Collection("users").Doc(*t.claimsProvider.GetUserID()).Update(ctx, []firestore.Update{
+		{Path: "weeklyDigest", Value: model.WeeklyDigest},
+		{Path: "digestWeekday", Value: model.DigestWeekday},
+		{Path: "monthEndDigest", Value: model.MonthEndDigest},
+	})
+	return err
+}
+func (t *users) UpdateDigestSentAt(model *models.User) error {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	_, err := client.Collection("users").Doc(*t.claimsProvider.GetUserID()).Update(ctx, []firestore.Update{
+		{Path: "lastWeeklyDigestAt", Value: model.LastWeeklyDigestAt},
+		{Path: "lastMonthEndDigestAt", Value: model.LastMonthEndDigestAt},
+	})
+	return err
+}
+
+// GetDigestSubscribers はまとめメールを受け取るユーザーを取得します
+// ユーザーに依らず全てのユーザーから検索します
+func (t *users) GetDigestSubscribers() (*[]models.User, error) {
+	client := t.provider.GetClient()
+	ctx := context.Background()
+	subscribers := make([]models.User, 0)
+	exists := make(map[string]bool)
+	for _, field := range []string{"weeklyDigest", "monthEndDigest"} {
+		iter := client.Collection("users").Where(field, "==", true).Documents(ctx)
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if exists[doc.Ref.ID] {
+				continue
+			}
+			var model models.User
+			if err := doc.DataTo(&model); err != nil {
+				return nil, err
+			}
+			model.UserID = doc.Ref.ID
+			exists[doc.Ref.ID] = true
+			subscribers = append(subscribers, model)
+		}
+	}
+	return &subscribers, nil
+}
@@ -4,10 +4,13 @@ import (
 	"log"
 	"reflect"
 
+	"github.com/wakuwaku3/account-book.api/src/adapter/blobs"
 	"github.com/wakuwaku3/account-book.api/src/adapter/crypt"
 	"github.com/wakuwaku3/account-book.api/src/adapter/event"
 	handler "github.com/wakuwaku3/account-book.api/src/adapter/event/handlers"
+	"github.com/wakuwaku3/account-book.api/src/adapter/images"
 	"github.com/wakuwaku3/account-book.api/src/adapter/mails/sendgrid"
+	"github.com/wakuwaku3/account-book.api/src/adapter/notifiers"
 	"github.com/wakuwaku3/account-book.api/src/adapter/system"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 
@@ -68,6 +71,12 @@ func CreateContainer() (dijct.Container, error) {
 	if err := container.Register(event.NewSubscriber, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
 		return nil, err
 	}
+	if err := container.Register(images.NewThumbnailer, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
+		return nil, err
+	}
+	if err := container.Register(blobs.NewLocal); err != nil {
+		return nil, err
+	}
 
 	// mails
 	if err := container.Register(mails.NewResetPassword, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
@@ -79,83 +88,209 @@ func CreateContainer() (dijct.Container, error) {
 	if err := container.Register(mails.NewUserExisting, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
 		return nil, err
 	}
+	if err := container.Register(mails.NewWeeklyDigest, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
+		return nil, err
+	}
+	if err := container.Register(mails.NewMonthEndDigest, dijct.RegisterOptions{LifetimeScope: dijct.ContainerManaged}); err != nil {
+		return nil, err
+	}
 
 	// controllers
 	if err := container.Register(ctrls.NewAccounts); err != nil {
 		return nil, err
 	}
+	if err := container.Register(ctrls.NewSettings); err != nil {
+		return nil, err
+	}
 	if err := container.Register(ctrls.NewTransactions); err != nil {
 		return nil, err
 	}
+	if err := container.Register(ctrls.NewAttachments); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewPayees); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewBudgets); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewCategorizationRules); err != nil {
+		return nil, err
+	}
 	if err := container.Register(ctrls.NewPlans); err != nil {
 		return nil, err
 	}
 	if err := container.Register(ctrls.NewDashboard); err != nil {
 		return nil, err
 	}
+	if err := container.Register(ctrls.NewForecast); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewReports); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewAggregates); err != nil {
+		return nil, err
+	}
 	if err := container.Register(ctrls.NewActual); err != nil {
 		return nil, err
 	}
 	if err := container.Register(ctrls.NewNotificationRules); err != nil {
 		return nil, err
 	}
+	if err := container.Register(ctrls.NewNotificationChannels); err != nil {
+		return nil, err
+	}
+	if err := container.Register(ctrls.NewNotifications); err != nil {
+		return nil, err
+	}
 
 	// usecases
 	if err := container.Register(usecases.NewAccounts); err != nil {
 		return nil, err
 	}
+	if err := container.Register(usecases.NewSettings); err != nil {
+		return nil, err
+	}
 	if err := container.Register(usecases.NewTransactions); err != nil {
 		return nil, err
 	}
+	if err := container.Register(usecases.NewAttachments); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewPayees); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewBudgets); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewCategorizationRules); err != nil {
+		return nil, err
+	}
 	if err := container.Register(usecases.NewPlans); err != nil {
 		return nil, err
 	}
 	if err := container.Register(usecases.NewDashboard); err != nil {
 		return nil, err
 	}
+	if err := container.Register(usecases.NewForecast); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewReports); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewAggregates); err != nil {
+		return nil, err
+	}
 	if err := container.Register(usecases.NewActual); err != nil {
 		return nil, err
 	}
 	if err := container.Register(usecases.NewNotificationRules); err != nil {
 		return nil, err
 	}
+	if err := container.Register(usecases.NewNotificationChannels); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewNotifications); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewAlerts); err != nil {
+		return nil, err
+	}
+	if err := container.Register(usecases.NewDigests); err != nil {
+		return nil, err
+	}
 
 	// queries
 	if err := container.Register(queries.NewAccounts); err != nil {
 		return nil, err
 	}
+	if err := container.Register(queries.NewSettings); err != nil {
+		return nil, err
+	}
 	if err := container.Register(queries.NewTransactions); err != nil {
 		return nil, err
 	}
+	if err := container.Register(queries.NewAttachments); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewPayees); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewBudgets); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewCategorizationRules); err != nil {
+		return nil, err
+	}
 	if err := container.Register(queries.NewPlans); err != nil {
 		return nil, err
 	}
 	if err := container.Register(queries.NewDashboard); err != nil {
 		return nil, err
 	}
+	if err := container.Register(queries.NewForecast); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewReports); err != nil {
+		return nil, err
+	}
 	if err := container.Register(queries.NewActual); err != nil {
 		return nil, err
 	}
 	if err := container.Register(queries.NewNotificationRules); err != nil {
 		return nil, err
 	}
+	if err := container.Register(queries.NewNotificationChannels); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewNotifications); err != nil {
+		return nil, err
+	}
+	if err := container.Register(queries.NewDigests); err != nil {
+		return nil, err
+	}
 
 	// services
 	if err := container.Register(services.NewAccounts); err != nil {
 		return nil, err
 	}
+	if err := container.Register(services.NewSettings); err != nil {
+		return nil, err
+	}
 	if err := container.Register(services.NewTransactions); err != nil {
 		return nil, err
 	}
+	if err := container.Register(services.NewAttachments); err != nil {
+		return nil, err
+	}
+	if err := container.Register(services.NewPayees); err != nil {
+		return nil, err
+	}
+	if err := container.Register(services.NewBudgets); err != nil {
+		return nil, err
+	}
+	if err := container.Register(services.NewCategorizationRules); err != nil {
+		return nil, err
+	}
 	if err := container.Register(services.NewPlans); err != nil {
 		return nil, err
 	}
 	if err := container.Register(services.NewDashboard); err != nil {
 		return nil, err
 	}
+	if err := container.Register(services.NewAggregates); err != nil {
+		return nil, err
+	}
 	if err := container.Register(services.NewActual); err != nil {
 		return nil, err
 	}
+	if err := container.Register(services.NewAlerts); err != nil {
+		return nil, err
+	}
+	if err := container.Register(services.NewDigests); err != nil {
+		return nil, err
+	}
 
 	// repos
 	if err := container.Register(repos.NewUsers); err != nil {
@@ -167,25 +302,60 @@ func CreateContainer() (dijct.Container, error) {
 	if err := container.Register(repos.NewTransactions); err != nil {
 		return nil, err
 	}
+	if err := container.Register(repos.NewPayees); err != nil {
+		return nil, err
+	}
+	if err := container.Register(repos.NewBudgets); err != nil {
+		return nil, err
+	}
+	if err := container.Register(repos.NewCategorizationRules); err != nil {
+		return nil, err
+	}
 	if err := container.Register(repos.NewPlans); err != nil {
 		return nil, err
 	}
 	if err := container.Register(repos.NewDashboard); err != nil {
 		return nil, err
 	}
+	if err := container.Register(repos.NewAggregates); err != nil {
+		return nil, err
+	}
 	if err := container.Register(repos.NewNotificationRules); err != nil {
 		return nil, err
 	}
+	if err := container.Register(repos.NewChannelSettings); err != nil {
+		return nil, err
+	}
+	if err := container.Register(repos.NewInbox); err != nil {
+		return nil, err
+	}
 
 	//events
 	if err := container.Register(accountbook.NewAssetsChangedEvent); err != nil {
 		return nil, err
 	}
 
+	// notifiers
+	if err := container.Register(notifiers.NewEmail); err != nil {
+		return nil, err
+	}
+	if err := container.Register(notifiers.NewWebhook); err != nil {
+		return nil, err
+	}
+	if err := container.Register(notifiers.NewInApp); err != nil {
+		return nil, err
+	}
+	if err := container.Register(notifiers.NewDispatcher); err != nil {
+		return nil, err
+	}
+
 	// handler
 	if err := container.Register(handler.NewAlert); err != nil {
 		return nil, err
 	}
+	if err := container.Register(handler.NewDigest); err != nil {
+		return nil, err
+	}
 
 	// initialize
 	if err := container.Invoke(initialize); err != nil {
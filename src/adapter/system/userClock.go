@@ -0,0 +1,35 @@
+package system
+
+import (
+	"reflect"
+
+	"github.com/tampopos/dijct"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+)
+
+// NewUserClock はログインしているユーザーの設定を反映した時計を生成します
+// 月の区切りを誤ったまま取引や集計を読み書きしないよう、ユーザーを取得できない場合はエラーを返します
+func NewUserClock(users application.UsersRepository, claimsProvider core.ClaimsProvider) (core.Clock, error) {
+	user, err := users.GetByAuth()
+	if err != nil {
+		return nil, err
+	}
+	return core.NewClockWithLocation(accountbook.UserClockOption(user, *claimsProvider.GetTimeZone())), nil
+}
+
+// RegisterUserClock はユーザーの設定を反映した時計を生成し、コンテナに登録します
+// 時計はユーザーを 1 度だけ取得して生成し、以降は生成した時計を使います
+func RegisterUserClock(container dijct.Container, claimsProvider core.ClaimsProvider) error {
+	var clock core.Clock
+	if err := container.Invoke(func(users application.UsersRepository) error {
+		c, err := NewUserClock(users, claimsProvider)
+		clock = c
+		return err
+	}); err != nil {
+		return err
+	}
+	ifs := []reflect.Type{reflect.TypeOf((*core.Clock)(nil)).Elem()}
+	return container.Register(clock, dijct.RegisterOptions{Interfaces: ifs})
+}
@@ -4,10 +4,13 @@ import (
 	"reflect"
 
 	"github.com/wakuwaku3/account-book.api/src/adapter/auth"
+	"github.com/wakuwaku3/account-book.api/src/adapter/system"
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo"
+	"github.com/labstack/gommon/log"
 	"github.com/tampopos/dijct"
 )
 
@@ -22,9 +25,17 @@ func Authenticate() echo.MiddlewareFunc {
 			claims := token.Claims.(jwt.MapClaims)
 			email := claims["email"].(string)
 			userID := claims["nonce"].(string)
-			claimsProvider := auth.NewClaimsProvider(email, userID, true)
+			// 以前に発行したトークンにはタイムゾーンが含まれません
+			timeZone, _ := claims["zoneinfo"].(string)
+			claimsProvider := auth.NewClaimsProvider(email, userID, timeZone, true)
 			ifs := []reflect.Type{reflect.TypeOf((*core.ClaimsProvider)(nil)).Elem()}
 			container.Register(claimsProvider, dijct.RegisterOptions{Interfaces: ifs})
+			// タイムゾーンや月の開始日などユーザーの設定を反映した時計に差し替えます
+			// 削除されたユーザーのトークンなど、ユーザーを取得できない場合は認証エラーとします
+			if err := system.RegisterUserClock(container, claimsProvider); err != nil {
+				log.Warnf("Authenticate userID:%s, error:%v", userID, err)
+				return responses.WriteUnAuthorizedErrorResponse(c)
+			}
 			return next(c)
 		}
 	}
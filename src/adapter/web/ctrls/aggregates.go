@@ -0,0 +1,58 @@
+package ctrls
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	aggregates struct {
+		useCase usecases.Aggregates
+		clock   core.Clock
+	}
+	// Aggregates is AggregatesController
+	Aggregates interface {
+		Repair(c echo.Context) error
+	}
+	repairAggregatesResponse struct {
+		Months []time.Time `json:"months"`
+	}
+)
+
+// NewAggregates is create instance
+func NewAggregates(useCase usecases.Aggregates, clock core.Clock) Aggregates {
+	return &aggregates{useCase, clock}
+}
+
+func (t *aggregates) Repair(c echo.Context) error {
+	var err error
+	to := t.clock.Now()
+	if s := c.QueryParam("to"); s != "" {
+		to, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			return responses.WriteErrorResponse(c, core.NewError(application.RequiredMonth))
+		}
+	}
+	from := to
+	if s := c.QueryParam("from"); s != "" {
+		from, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			return responses.WriteErrorResponse(c, core.NewError(application.RequiredMonth))
+		}
+	}
+	res, err := t.useCase.Repair(&usecases.RepairAggregatesArgs{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, repairAggregatesResponse{Months: res.Months})
+}
@@ -0,0 +1,132 @@
+package ctrls
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	attachments struct {
+		useCase usecases.Attachments
+	}
+	// Attachments is AttachmentsController
+	Attachments interface {
+		GetAttachments(c echo.Context) error
+		GetAttachment(c echo.Context) error
+		Upload(c echo.Context) error
+		Delete(c echo.Context) error
+	}
+	getAttachmentsResponse struct {
+		Attachments []getAttachmentResponse `json:"attachments"`
+	}
+	getAttachmentResponse struct {
+		AttachmentID string    `json:"id"`
+		FileName     string    `json:"fileName"`
+		ContentType  string    `json:"contentType"`
+		Size         int       `json:"size"`
+		HasThumbnail bool      `json:"hasThumbnail"`
+		CreatedAt    time.Time `json:"createdAt"`
+	}
+	uploadAttachmentResponse struct {
+		AttachmentID string `json:"id"`
+	}
+)
+
+// NewAttachments is create instance
+func NewAttachments(useCase usecases.Attachments) Attachments {
+	return &attachments{useCase}
+}
+
+func (t *attachments) GetAttachments(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetAttachments(&id)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	attachments := make([]getAttachmentResponse, len(res.Attachments))
+	for i, attachment := range res.Attachments {
+		attachments[i] = getAttachmentResponse{
+			AttachmentID: attachment.AttachmentID,
+			FileName:     attachment.FileName,
+			ContentType:  attachment.ContentType,
+			Size:         attachment.Size,
+			HasThumbnail: attachment.HasThumbnail,
+			CreatedAt:    attachment.CreatedAt,
+		}
+	}
+	return responses.WriteResponse(c, getAttachmentsResponse{Attachments: attachments})
+}
+func (t *attachments) GetAttachment(c echo.Context) error {
+	id := c.Param("id")
+	attachmentID := c.Param("attachmentId")
+	if id == "" || attachmentID == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetAttachment(&usecases.GetAttachmentArgs{
+		TransactionID: id,
+		AttachmentID:  attachmentID,
+		Thumbnail:     c.QueryParam("thumbnail") == "true",
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	disposition := mime.FormatMediaType("inline", map[string]string{"filename": res.FileName})
+	c.Response().Header().Set("Content-Disposition", disposition)
+	return c.Blob(http.StatusOK, res.ContentType, res.Data)
+}
+func (t *attachments) Upload(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredFile))
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	// 上限を超えたことを判定できるよう 1 バイト余分に読み込む
+	data, err := ioutil.ReadAll(io.LimitReader(src, usecases.MaxAttachmentSize+1))
+	if err != nil {
+		return err
+	}
+	res, err := t.useCase.Upload(&usecases.UploadAttachmentArgs{
+		TransactionID: id,
+		FileName:      file.Filename,
+		Data:          data,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, uploadAttachmentResponse{
+		AttachmentID: res.AttachmentID,
+	})
+}
+func (t *attachments) Delete(c echo.Context) error {
+	id := c.Param("id")
+	attachmentID := c.Param("attachmentId")
+	if id == "" || attachmentID == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Delete(&id, &attachmentID); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
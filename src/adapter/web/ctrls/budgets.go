@@ -0,0 +1,155 @@
+package ctrls
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	budgets struct {
+		useCase usecases.Budgets
+		clock   core.Clock
+	}
+	// Budgets is BudgetsController
+	Budgets interface {
+		GetBudgets(c echo.Context) error
+		GetBudget(c echo.Context) error
+		GetBudgetReport(c echo.Context) error
+		Create(c echo.Context) error
+		Update(c echo.Context) error
+		Delete(c echo.Context) error
+	}
+	getBudgetsResponse struct {
+		Budgets []getBudgetResponse `json:"budgets"`
+	}
+	getBudgetResponse struct {
+		BudgetID string    `json:"id"`
+		Category int       `json:"categoryId,string"`
+		Month    time.Time `json:"month"`
+		Amount   int       `json:"amount"`
+		Rollover bool      `json:"rollover"`
+	}
+	budgetRequest struct {
+		Category *int       `json:"categoryId,string,omitempty"`
+		Month    *time.Time `json:"month,omitempty"`
+		Amount   int        `json:"amount"`
+		Rollover bool       `json:"rollover"`
+	}
+	createBudgetResponse struct {
+		BudgetID string `json:"id"`
+	}
+	getBudgetReportResponse struct {
+		SelectedMonth time.Time                      `json:"selectedMonth"`
+		Categories    []budgetReportCategoryResponse `json:"categories"`
+	}
+	budgetReportCategoryResponse struct {
+		Category  int     `json:"categoryId,string"`
+		BudgetID  *string `json:"budgetId,omitempty"`
+		Budgeted  int     `json:"budgeted"`
+		Rollover  int     `json:"rollover"`
+		Spent     int     `json:"spent"`
+		Remaining int     `json:"remaining"`
+	}
+)
+
+// NewBudgets is create instance
+func NewBudgets(useCase usecases.Budgets, clock core.Clock) Budgets {
+	return &budgets{useCase, clock}
+}
+
+func (t *budgets) GetBudgets(c echo.Context) error {
+	res, err := t.useCase.GetBudgets()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	x := make([]getBudgetResponse, len(res.Budgets))
+	for i, budget := range res.Budgets {
+		x[i] = getBudgetResponse(budget)
+	}
+	return responses.WriteResponse(c, getBudgetsResponse{Budgets: x})
+}
+func (t *budgets) GetBudget(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetBudget(&id)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, getBudgetResponse(*res))
+}
+func (t *budgets) GetBudgetReport(c echo.Context) error {
+	var err error
+	selectedMonth := t.clock.Now()
+	if month := c.QueryParam("month"); month != "" {
+		selectedMonth, err = time.Parse("2006-01-02", month)
+		if err != nil {
+			return err
+		}
+	}
+	res, err := t.useCase.GetBudgetReport(&usecases.GetBudgetReportArgs{
+		SelectedMonth: selectedMonth,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	x := make([]budgetReportCategoryResponse, len(res.Categories))
+	for i, category := range res.Categories {
+		x[i] = budgetReportCategoryResponse(category)
+	}
+	return responses.WriteResponse(c, getBudgetReportResponse{
+		SelectedMonth: res.SelectedMonth,
+		Categories:    x,
+	})
+}
+func (t *budgets) Create(c echo.Context) error {
+	request := new(budgetRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	res, err := t.useCase.Create(request.convert())
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, createBudgetResponse{BudgetID: res.BudgetID})
+}
+func (t *budgetRequest) convert() *usecases.BudgetArgs {
+	return &usecases.BudgetArgs{
+		Category: t.Category,
+		Month:    t.Month,
+		Amount:   t.Amount,
+		Rollover: t.Rollover,
+	}
+}
+func (t *budgets) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	request := new(budgetRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.Update(&id, request.convert()); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *budgets) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Delete(&id); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
@@ -0,0 +1,188 @@
+package ctrls
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	categorizationRules struct {
+		useCase usecases.CategorizationRules
+		clock   core.Clock
+	}
+	// CategorizationRules is CategorizationRulesController
+	CategorizationRules interface {
+		GetCategorizationRules(c echo.Context) error
+		GetCategorizationRule(c echo.Context) error
+		Create(c echo.Context) error
+		Update(c echo.Context) error
+		Delete(c echo.Context) error
+		Apply(c echo.Context) error
+	}
+	getCategorizationRulesResponse struct {
+		CategorizationRules []getCategorizationRuleResponse `json:"rules"`
+	}
+	getCategorizationRuleResponse struct {
+		RuleID string `json:"id"`
+		categorizationRuleRequest
+	}
+	categorizationRuleRequest struct {
+		RuleName     string   `json:"name"`
+		Priority     int      `json:"priority"`
+		NotesPattern *string  `json:"notesPattern,omitempty"`
+		PayeePattern *string  `json:"payeePattern,omitempty"`
+		MinAmount    *int     `json:"minAmount,omitempty"`
+		MaxAmount    *int     `json:"maxAmount,omitempty"`
+		ImportSource *string  `json:"importSource,omitempty"`
+		Category     *int     `json:"categoryId,string,omitempty"`
+		Tags         []string `json:"tags,omitempty"`
+		PayeeID      *string  `json:"payeeId,omitempty"`
+	}
+	createCategorizationRuleResponse struct {
+		RuleID string `json:"id"`
+	}
+	applyCategorizationRulesResponse struct {
+		Preview bool                           `json:"preview"`
+		Changes []categorizationChangeResponse `json:"changes"`
+	}
+	categorizationChangeResponse struct {
+		TransactionID  string   `json:"id"`
+		BeforeCategory int      `json:"beforeCategoryId,string"`
+		Category       int      `json:"categoryId,string"`
+		PayeeID        *string  `json:"payeeId,omitempty"`
+		Tags           []string `json:"tags,omitempty"`
+	}
+)
+
+// NewCategorizationRules is create instance
+func NewCategorizationRules(useCase usecases.CategorizationRules, clock core.Clock) CategorizationRules {
+	return &categorizationRules{useCase, clock}
+}
+
+func (t *categorizationRules) GetCategorizationRules(c echo.Context) error {
+	res, err := t.useCase.GetCategorizationRules()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	rules := make([]getCategorizationRuleResponse, len(res.CategorizationRules))
+	for i, rule := range res.CategorizationRules {
+		rules[i] = convertCategorizationRule(rule)
+	}
+	return responses.WriteResponse(c, getCategorizationRulesResponse{CategorizationRules: rules})
+}
+func convertCategorizationRule(rule usecases.GetCategorizationRuleResult) getCategorizationRuleResponse {
+	return getCategorizationRuleResponse{
+		RuleID: rule.RuleID,
+		categorizationRuleRequest: categorizationRuleRequest{
+			RuleName:     rule.RuleName,
+			Priority:     rule.Priority,
+			NotesPattern: rule.NotesPattern,
+			PayeePattern: rule.PayeePattern,
+			MinAmount:    rule.MinAmount,
+			MaxAmount:    rule.MaxAmount,
+			ImportSource: rule.ImportSource,
+			Category:     rule.Category,
+			Tags:         rule.Tags,
+			PayeeID:      rule.PayeeID,
+		},
+	}
+}
+func (t *categorizationRules) GetCategorizationRule(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetCategorizationRule(&id)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, convertCategorizationRule(*res))
+}
+func (t *categorizationRules) Create(c echo.Context) error {
+	request := new(categorizationRuleRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	res, err := t.useCase.Create(request.convert())
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, createCategorizationRuleResponse{RuleID: res.RuleID})
+}
+func (t *categorizationRuleRequest) convert() *usecases.CategorizationRuleArgs {
+	return &usecases.CategorizationRuleArgs{
+		RuleName:     t.RuleName,
+		Priority:     t.Priority,
+		NotesPattern: t.NotesPattern,
+		PayeePattern: t.PayeePattern,
+		MinAmount:    t.MinAmount,
+		MaxAmount:    t.MaxAmount,
+		ImportSource: t.ImportSource,
+		Category:     t.Category,
+		Tags:         t.Tags,
+		PayeeID:      t.PayeeID,
+	}
+}
+func (t *categorizationRules) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	request := new(categorizationRuleRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.Update(&id, request.convert()); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *categorizationRules) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Delete(&id); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *categorizationRules) Apply(c echo.Context) error {
+	var err error
+	selectedMonth := t.clock.Now()
+	if month := c.QueryParam("month"); month != "" {
+		selectedMonth, err = time.Parse("2006-01-02", month)
+		if err != nil {
+			return err
+		}
+	}
+	preview := c.QueryParam("preview") == "true"
+	res, err := t.useCase.Apply(&usecases.ApplyCategorizationRulesArgs{
+		SelectedMonth: selectedMonth,
+		Preview:       preview,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	changes := make([]categorizationChangeResponse, len(res.Changes))
+	for i, change := range res.Changes {
+		changes[i] = categorizationChangeResponse{
+			TransactionID:  change.TransactionID,
+			BeforeCategory: change.BeforeCategory,
+			Category:       change.Category,
+			PayeeID:        change.PayeeID,
+			Tags:           change.Tags,
+		}
+	}
+	return responses.WriteResponse(c, applyCategorizationRulesResponse{
+		Preview: preview,
+		Changes: changes,
+	})
+}
@@ -21,18 +21,21 @@ type (
 	Dashboard interface {
 		GetDashboard(c echo.Context) error
 		Approve(c echo.Context) error
+		ApproveThrough(c echo.Context) error
 		CancelApprove(c echo.Context) error
 		AdjustBalance(c echo.Context) error
+		GetAdjustments(c echo.Context) error
 	}
 	getDashboardResponse struct {
-		DashboardID      string                      `json:"id"`
-		SelectedMonth    time.Time                   `json:"selectedMonth"`
-		Summary          getDashboardSummaryResponse `json:"summary"`
-		Plans            []getDashboardPlanResponse  `json:"plans"`
-		Daily            []getDashboardDailyResponse `json:"daily"`
-		State            string                      `json:"state"`
-		CanApprove       bool                        `json:"canApprove"`
-		CanCancelApprove bool                        `json:"canCancelApprove"`
+		DashboardID      string                         `json:"id"`
+		SelectedMonth    time.Time                      `json:"selectedMonth"`
+		Summary          getDashboardSummaryResponse    `json:"summary"`
+		Plans            []getDashboardPlanResponse     `json:"plans"`
+		Daily            []getDashboardDailyResponse    `json:"daily"`
+		Categories       []getDashboardCategoryResponse `json:"categories"`
+		State            string                         `json:"state"`
+		CanApprove       bool                           `json:"canApprove"`
+		CanCancelApprove bool                           `json:"canCancelApprove"`
 	}
 	getDashboardSummaryResponse struct {
 		Income          int  `json:"income"`
@@ -54,8 +57,40 @@ type (
 		Expense int       `json:"expense"`
 		Balance int       `json:"balance"`
 	}
+	getDashboardCategoryResponse struct {
+		Category      int     `json:"categoryId,string"`
+		Amount        int     `json:"amount"`
+		Share         float64 `json:"share"`
+		Count         int     `json:"count"`
+		Uncategorized bool    `json:"uncategorized"`
+	}
+	approveThroughResponse struct {
+		ApprovedMonths []time.Time `json:"approvedMonths"`
+		StoppedMonth   *time.Time  `json:"stoppedMonth,omitempty"`
+		MissingPlanIDs []string    `json:"missingPlanIds,omitempty"`
+	}
+	cancelApproveCascadeResponse struct {
+		ReopenedMonths []time.Time `json:"reopenedMonths"`
+		ReclosedMonths []time.Time `json:"reclosedMonths"`
+		StoppedMonth   *time.Time  `json:"stoppedMonth,omitempty"`
+		MissingPlanIDs []string    `json:"missingPlanIds,omitempty"`
+	}
 	adjustBalanceRequest struct {
-		Balance int `json:"balance"`
+		Amount int    `json:"amount"`
+		Reason string `json:"reason"`
+	}
+	adjustBalanceResponse struct {
+		AdjustmentID string `json:"id"`
+		Balance      int    `json:"balance"`
+	}
+	getAdjustmentsResponse struct {
+		Adjustments []adjustmentResponse `json:"adjustments"`
+	}
+	adjustmentResponse struct {
+		AdjustmentID string    `json:"id"`
+		Amount       int       `json:"amount"`
+		Reason       string    `json:"reason"`
+		CreatedAt    time.Time `json:"createdAt"`
 	}
 )
 
@@ -109,8 +144,13 @@ func convertDashboard(t *usecases.GetDashboardResult) getDashboardResponse {
 			Expense: d.Expense,
 		}
 	}
+	categories := make([]getDashboardCategoryResponse, len(t.Categories))
+	for i, category := range t.Categories {
+		categories[i] = getDashboardCategoryResponse(category)
+	}
 	return getDashboardResponse{
 		DashboardID:      t.DashboardID,
+		Categories:       categories,
 		SelectedMonth:    t.SelectedMonth,
 		Plans:            plans,
 		Daily:            daily,
@@ -136,11 +176,35 @@ func (t *dashboard) Approve(c echo.Context) error {
 	}
 	return responses.WriteEmptyResponse(c)
 }
+func (t *dashboard) ApproveThrough(c echo.Context) error {
+	month, err := time.Parse("2006-01-02", c.QueryParam("month"))
+	if err != nil {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredMonth))
+	}
+	res, err := t.useCase.ApproveThrough(&usecases.ApproveThroughArgs{
+		Month:  month,
+		Atomic: c.QueryParam("atomic") == "true",
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, approveThroughResponse(*res))
+}
 func (t *dashboard) CancelApprove(c echo.Context) error {
 	id := c.Param("id")
 	if id == "" {
 		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
 	}
+	if c.QueryParam("cascade") == "true" {
+		res, err := t.useCase.CancelApproveCascade(&usecases.CancelApproveCascadeArgs{
+			DashboardID: id,
+			Reclose:     c.QueryParam("reclose") == "true",
+		})
+		if err != nil {
+			return responses.WriteErrorResponse(c, err)
+		}
+		return responses.WriteResponse(c, cancelApproveCascadeResponse(*res))
+	}
 	if err := t.useCase.CancelApprove(&id); err != nil {
 		return responses.WriteErrorResponse(c, err)
 	}
@@ -155,11 +219,28 @@ func (t *dashboard) AdjustBalance(c echo.Context) error {
 	if err := c.Bind(&request); err != nil {
 		return err
 	}
-	if err := t.useCase.AdjustBalance(&usecases.AdjustBalanceArgs{
+	res, err := t.useCase.AdjustBalance(&usecases.AdjustBalanceArgs{
 		DashboardID: id,
-		Balance:     request.Balance,
-	}); err != nil {
-		return err
+		Amount:      request.Amount,
+		Reason:      request.Reason,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
 	}
-	return responses.WriteEmptyResponse(c)
+	return responses.WriteResponse(c, adjustBalanceResponse(*res))
+}
+func (t *dashboard) GetAdjustments(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetAdjustments(&id)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	adjustments := make([]adjustmentResponse, len(res.Adjustments))
+	for i, adjustment := range res.Adjustments {
+		adjustments[i] = adjustmentResponse(adjustment)
+	}
+	return responses.WriteResponse(c, getAdjustmentsResponse{Adjustments: adjustments})
 }
@@ -0,0 +1,67 @@
+package ctrls
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	forecast struct {
+		useCase usecases.Forecast
+	}
+	// Forecast is ForecastController
+	Forecast interface {
+		GetForecast(c echo.Context) error
+	}
+	getForecastResponse struct {
+		StartBalance int                        `json:"startBalance"`
+		Months       []getForecastMonthResponse `json:"months"`
+	}
+	getForecastMonthResponse struct {
+		Month            time.Time `json:"month"`
+		Income           int       `json:"income"`
+		Expense          int       `json:"expense"`
+		UnplannedIncome  int       `json:"unplannedIncome"`
+		UnplannedExpense int       `json:"unplannedExpense"`
+		Balance          int       `json:"balance"`
+	}
+)
+
+// NewForecast is create instance
+func NewForecast(useCase usecases.Forecast) Forecast {
+	return &forecast{useCase}
+}
+
+func (t *forecast) GetForecast(c echo.Context) error {
+	months := 0
+	if m := c.QueryParam("months"); m != "" {
+		var err error
+		months, err = strconv.Atoi(m)
+		if err != nil {
+			return responses.WriteErrorResponse(c, core.NewError(application.InValidForecastMonths))
+		}
+	}
+	res, err := t.useCase.GetForecast(&usecases.GetForecastArgs{
+		Months:           months,
+		IncludeUnplanned: c.QueryParam("includeUnplanned") == "true",
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	x := make([]getForecastMonthResponse, len(res.Months))
+	for i, month := range res.Months {
+		x[i] = getForecastMonthResponse(month)
+	}
+	return responses.WriteResponse(c, getForecastResponse{
+		StartBalance: res.StartBalance,
+		Months:       x,
+	})
+}
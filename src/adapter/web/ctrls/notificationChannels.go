@@ -0,0 +1,51 @@
+package ctrls
+
+import (
+	"github.com/labstack/echo"
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type (
+	notificationChannels struct {
+		useCase usecases.NotificationChannels
+	}
+	// NotificationChannels は通知の配信先の設定のコントローラーです
+	NotificationChannels interface {
+		Get(c echo.Context) error
+		Update(c echo.Context) error
+	}
+	getNotificationChannelsResponse struct {
+		WebhookURL       string `json:"webhookUrl"`
+		HasWebhookSecret bool   `json:"hasWebhookSecret"`
+	}
+	notificationChannelsRequest struct {
+		WebhookURL    string `json:"webhookUrl"`
+		WebhookSecret string `json:"webhookSecret"`
+	}
+)
+
+// NewNotificationChannels is create instance
+func NewNotificationChannels(useCase usecases.NotificationChannels) NotificationChannels {
+	return &notificationChannels{useCase}
+}
+func (t *notificationChannels) Get(c echo.Context) error {
+	res := t.useCase.GetNotificationChannels()
+	return responses.WriteResponse(c, getNotificationChannelsResponse{
+		WebhookURL:       res.WebhookURL,
+		HasWebhookSecret: res.HasWebhookSecret,
+	})
+}
+func (t *notificationChannels) Update(c echo.Context) error {
+	request := new(notificationChannelsRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.Update(&usecases.NotificationChannelsArgs{
+		WebhookURL:    request.WebhookURL,
+		WebhookSecret: request.WebhookSecret,
+	}); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
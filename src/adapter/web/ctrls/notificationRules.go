@@ -1,6 +1,8 @@
 package ctrls
 
 import (
+	"time"
+
 	"github.com/labstack/echo"
 	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
 	"github.com/wakuwaku3/account-book.api/src/application"
@@ -12,6 +14,7 @@ type (
 	notificationRules struct {
 		useCase usecases.NotificationRules
 	}
+	// NotificationRules is NotificationRulesController
 	NotificationRules interface {
 		GetNotificationRules(c echo.Context) error
 		GetNotificationRule(c echo.Context) error
@@ -23,13 +26,29 @@ type (
 		NotificationRules []getNotificationRuleResponse `json:"notificationRules"`
 	}
 	getNotificationRuleResponse struct {
-		NotificationRuleID string `json:"id"`
-		Metrics            string `json:"metrics"`
-		Threshold          int    `json:"threshold"`
+		NotificationRuleID string     `json:"id"`
+		Metrics            string     `json:"metrics"`
+		Threshold          int        `json:"threshold"`
+		ThresholdUnit      string     `json:"thresholdUnit"`
+		Operator           string     `json:"operator"`
+		Period             string     `json:"period"`
+		Category           *int       `json:"category"`
+		Channels           []string   `json:"channels"`
+		Rearm              bool       `json:"rearm"`
+		CooldownHours      int        `json:"cooldownHours"`
+		LastFiredAt        *time.Time `json:"lastFiredAt"`
+		LastValue          *int       `json:"lastValue"`
 	}
 	notificationRuleRequest struct {
-		Metrics   string `json:"metrics"`
-		Threshold int    `json:"threshold"`
+		Metrics       string   `json:"metrics"`
+		Threshold     int      `json:"threshold"`
+		ThresholdUnit string   `json:"thresholdUnit"`
+		Operator      string   `json:"operator"`
+		Period        string   `json:"period"`
+		Category      *int     `json:"category"`
+		Channels      []string `json:"channels"`
+		Rearm         *bool    `json:"rearm"`
+		CooldownHours int      `json:"cooldownHours"`
 	}
 	createNotificationRuleResponse struct {
 		NotificationRuleID string `json:"id"`
@@ -59,6 +78,15 @@ func convertNotificationRule(t usecases.GetNotificationRuleResult) getNotificati
 		NotificationRuleID: t.NotificationRuleID,
 		Metrics:            t.Metrics,
 		Threshold:          t.Threshold,
+		ThresholdUnit:      t.ThresholdUnit,
+		Operator:           t.Operator,
+		Period:             t.Period,
+		Category:           t.Category,
+		Channels:           t.Channels,
+		Rearm:              t.Rearm,
+		CooldownHours:      t.CooldownHours,
+		LastFiredAt:        t.LastFiredAt,
+		LastValue:          t.LastValue,
 	}
 }
 func (t *notificationRules) GetNotificationRule(c echo.Context) error {
@@ -87,8 +115,15 @@ func (t *notificationRules) Create(c echo.Context) error {
 }
 func (t *notificationRuleRequest) convert() *usecases.NotificationRuleArgs {
 	return &usecases.NotificationRuleArgs{
-		Metrics:   t.Metrics,
-		Threshold: t.Threshold,
+		Metrics:       t.Metrics,
+		Threshold:     t.Threshold,
+		ThresholdUnit: t.ThresholdUnit,
+		Operator:      t.Operator,
+		Period:        t.Period,
+		Category:      t.Category,
+		Channels:      t.Channels,
+		Rearm:         t.Rearm,
+		CooldownHours: t.CooldownHours,
 	}
 }
 
@@ -0,0 +1,94 @@
+package ctrls
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	inbox struct {
+		useCase usecases.Notifications
+	}
+	// Notifications is NotificationsController
+	Notifications interface {
+		GetNotifications(c echo.Context) error
+		GetUnreadCount(c echo.Context) error
+		Read(c echo.Context) error
+		ReadAll(c echo.Context) error
+	}
+	getNotificationsResponse struct {
+		Notifications []getNotificationResponse `json:"notifications"`
+		NextCursor    *string                   `json:"nextCursor"`
+	}
+	getNotificationResponse struct {
+		NotificationID     string     `json:"id"`
+		Title              string     `json:"title"`
+		Body               string     `json:"body"`
+		Severity           string     `json:"severity"`
+		NotificationRuleID string     `json:"notificationRuleId"`
+		CreatedAt          time.Time  `json:"createdAt"`
+		ReadAt             *time.Time `json:"readAt"`
+	}
+	getUnreadCountResponse struct {
+		Count int `json:"count"`
+	}
+	readAllNotificationsResponse struct {
+		Count int `json:"count"`
+	}
+)
+
+// NewNotifications is create instance
+func NewNotifications(useCase usecases.Notifications) Notifications {
+	return &inbox{useCase}
+}
+func (t *inbox) GetNotifications(c echo.Context) error {
+	args := &usecases.GetNotificationsArgs{
+		UnreadOnly: c.QueryParam("unread") == "true",
+	}
+	if l := c.QueryParam("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil {
+			return responses.WriteErrorResponse(c, core.NewError(application.InValidLimit))
+		}
+		args.Limit = limit
+	}
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		args.Cursor = &cursor
+	}
+	res, err := t.useCase.GetNotifications(args)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	x := make([]getNotificationResponse, len(res.Notifications))
+	for i, notification := range res.Notifications {
+		x[i] = getNotificationResponse(notification)
+	}
+	return responses.WriteResponse(c, getNotificationsResponse{
+		Notifications: x,
+		NextCursor:    res.NextCursor,
+	})
+}
+func (t *inbox) GetUnreadCount(c echo.Context) error {
+	res := t.useCase.GetUnreadCount()
+	return responses.WriteResponse(c, getUnreadCountResponse{Count: res.Count})
+}
+func (t *inbox) Read(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Read(&id); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *inbox) ReadAll(c echo.Context) error {
+	res := t.useCase.ReadAll()
+	return responses.WriteResponse(c, readAllNotificationsResponse{Count: res.Count})
+}
@@ -0,0 +1,110 @@
+package ctrls
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	payees struct {
+		useCase usecases.Payees
+	}
+	// Payees is PayeesController
+	Payees interface {
+		GetPayees(c echo.Context) error
+		GetPayee(c echo.Context) error
+		Create(c echo.Context) error
+		Update(c echo.Context) error
+		Delete(c echo.Context) error
+	}
+	getPayeesResponse struct {
+		Payees []getPayeeResponse `json:"payees"`
+	}
+	getPayeeResponse struct {
+		PayeeID   string `json:"id"`
+		PayeeName string `json:"name"`
+	}
+	payeeRequest struct {
+		PayeeName string `json:"name"`
+	}
+	createPayeeResponse struct {
+		PayeeID string `json:"id"`
+	}
+)
+
+// NewPayees is create instance
+func NewPayees(useCase usecases.Payees) Payees {
+	return &payees{useCase}
+}
+
+func (t *payees) GetPayees(c echo.Context) error {
+	res, err := t.useCase.GetPayees()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	payees := make([]getPayeeResponse, len(res.Payees))
+	for i, payee := range res.Payees {
+		payees[i] = convertPayee(payee)
+	}
+	return responses.WriteResponse(c, getPayeesResponse{Payees: payees})
+}
+func convertPayee(payee usecases.GetPayeeResult) getPayeeResponse {
+	return getPayeeResponse{
+		PayeeID:   payee.PayeeID,
+		PayeeName: payee.PayeeName,
+	}
+}
+func (t *payees) GetPayee(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	res, err := t.useCase.GetPayee(&id)
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, convertPayee(*res))
+}
+func (t *payees) Create(c echo.Context) error {
+	request := new(payeeRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	res, err := t.useCase.Create(request.convert())
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, createPayeeResponse{PayeeID: res.PayeeID})
+}
+func (t *payeeRequest) convert() *usecases.PayeeArgs {
+	return &usecases.PayeeArgs{PayeeName: t.PayeeName}
+}
+func (t *payees) Update(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	request := new(payeeRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.Update(&id, request.convert()); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *payees) Delete(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Delete(&id); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
@@ -23,26 +23,53 @@ type (
 		Create(c echo.Context) error
 		Update(c echo.Context) error
 		Delete(c echo.Context) error
+		Restore(c echo.Context) error
+		MigrateRecurrences(c echo.Context) error
 	}
 	getPlansResponse struct {
 		Plans []getPlanResponse `json:"plans"`
 	}
 	getPlanResponse struct {
-		PlanID     string     `json:"id"`
-		PlanName   string     `json:"name"`
-		IsIncome   bool       `json:"isIncome"`
-		PlanAmount int        `json:"amount"`
-		Interval   int        `json:"interval"`
-		Start      *time.Time `json:"start"`
-		End        *time.Time `json:"end"`
+		PlanID     string                 `json:"id"`
+		PlanName   string                 `json:"name"`
+		IsIncome   bool                   `json:"isIncome"`
+		PlanAmount int                    `json:"amount"`
+		Interval   int                    `json:"interval"`
+		Recurrence recurrenceResponse     `json:"recurrence"`
+		Start      *time.Time             `json:"start"`
+		End        *time.Time             `json:"end"`
+		Revisions  []planRevisionResponse `json:"revisions,omitempty"`
+		IsDeleted  bool                   `json:"isDeleted"`
+		DeletedAt  *time.Time             `json:"deletedAt,omitempty"`
+	}
+	planRevisionResponse struct {
+		EffectiveFrom time.Time `json:"effectiveFrom"`
+		PlanName      string    `json:"name"`
+		PlanAmount    int       `json:"amount"`
+		IsIncome      bool      `json:"isIncome"`
+		CreatedAt     time.Time `json:"createdAt"`
 	}
 	planRequest struct {
-		PlanName   string     `json:"name"`
-		IsIncome   bool       `json:"isIncome"`
-		PlanAmount int        `json:"amount"`
+		PlanName      string             `json:"name"`
+		IsIncome      bool               `json:"isIncome"`
+		PlanAmount    int                `json:"amount"`
+		Interval      int                `json:"interval"`
+		Recurrence    *recurrenceRequest `json:"recurrence,omitempty"`
+		Start         *time.Time         `json:"start"`
+		End           *time.Time         `json:"end"`
+		EffectiveFrom *time.Time         `json:"effectiveFrom,omitempty"`
+	}
+	recurrenceRequest struct {
+		Frequency  string     `json:"frequency"`
 		Interval   int        `json:"interval"`
-		Start      *time.Time `json:"start"`
-		End        *time.Time `json:"end"`
+		ByMonth    []int      `json:"byMonth,omitempty"`
+		ByMonthDay []int      `json:"byMonthDay,omitempty"`
+		Count      *int       `json:"count,omitempty"`
+		Until      *time.Time `json:"until,omitempty"`
+	}
+	recurrenceResponse         recurrenceRequest
+	migrateRecurrencesResponse struct {
+		Count int `json:"count"`
 	}
 	createPlanResponse struct {
 		PlanID string `json:"id"`
@@ -55,7 +82,9 @@ func NewPlans(useCase usecases.Plans) Plans {
 }
 
 func (t *plans) GetPlans(c echo.Context) error {
-	res, err := t.useCase.GetPlans()
+	res, err := t.useCase.GetPlans(&usecases.GetPlansArgs{
+		IncludeDeleted: c.QueryParam("includeDeleted") == "true",
+	})
 	if err != nil {
 		return responses.WriteErrorResponse(c, err)
 	}
@@ -71,14 +100,22 @@ func convertPlans(plans []usecases.GetPlanResult) []getPlanResponse {
 	return x
 }
 func convertPlan(t usecases.GetPlanResult) getPlanResponse {
+	revisions := make([]planRevisionResponse, len(t.Revisions))
+	for i, revision := range t.Revisions {
+		revisions[i] = planRevisionResponse(revision)
+	}
 	return getPlanResponse{
 		PlanID:     t.PlanID,
 		PlanName:   t.PlanName,
 		IsIncome:   t.IsIncome,
 		PlanAmount: t.PlanAmount,
 		Interval:   t.Interval,
+		Recurrence: recurrenceResponse(t.Recurrence),
 		Start:      t.Start,
 		End:        t.End,
+		Revisions:  revisions,
+		IsDeleted:  t.IsDeleted,
+		DeletedAt:  t.DeletedAt,
 	}
 }
 func (t *plans) GetPlan(c echo.Context) error {
@@ -106,14 +143,20 @@ func (t *plans) Create(c echo.Context) error {
 	})
 }
 func (t *planRequest) convert() *usecases.PlanArgs {
-	return &usecases.PlanArgs{
-		PlanName:   t.PlanName,
-		IsIncome:   t.IsIncome,
-		PlanAmount: t.PlanAmount,
-		Interval:   t.Interval,
-		Start:      t.Start,
-		End:        t.End,
-	}
+	args := &usecases.PlanArgs{
+		PlanName:      t.PlanName,
+		IsIncome:      t.IsIncome,
+		PlanAmount:    t.PlanAmount,
+		Interval:      t.Interval,
+		Start:         t.Start,
+		End:           t.End,
+		EffectiveFrom: t.EffectiveFrom,
+	}
+	if t.Recurrence != nil {
+		recurrence := usecases.RecurrenceArgs(*t.Recurrence)
+		args.Recurrence = &recurrence
+	}
+	return args
 }
 
 func (t *plans) Update(c echo.Context) error {
@@ -140,3 +183,20 @@ func (t *plans) Delete(c echo.Context) error {
 	}
 	return responses.WriteEmptyResponse(c)
 }
+func (t *plans) Restore(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.WriteErrorResponse(c, core.NewError(application.RequiredID))
+	}
+	if err := t.useCase.Restore(&id); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *plans) MigrateRecurrences(c echo.Context) error {
+	res, err := t.useCase.MigrateRecurrences()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, migrateRecurrencesResponse{Count: res.Count})
+}
@@ -0,0 +1,159 @@
+package ctrls
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	reports struct {
+		useCase usecases.Reports
+		clock   core.Clock
+	}
+	// Reports is ReportsController
+	Reports interface {
+		GetMonthlyReport(c echo.Context) error
+		GetYearlyReport(c echo.Context) error
+		GetNetWorth(c echo.Context) error
+	}
+	getMonthlyReportResponse struct {
+		Months []monthlyReportResponse `json:"months"`
+	}
+	monthlyReportResponse struct {
+		Month             time.Time `json:"month"`
+		Income            int       `json:"income"`
+		Expense           int       `json:"expense"`
+		Balance           int       `json:"balance"`
+		CumulativeBalance int       `json:"cumulativeBalance"`
+		State             string    `json:"state"`
+	}
+	getYearlyReportResponse struct {
+		Years []yearlyReportResponse `json:"years"`
+	}
+	yearlyReportResponse struct {
+		Year           int                     `json:"year"`
+		Income         int                     `json:"income"`
+		Expense        int                     `json:"expense"`
+		Balance        int                     `json:"balance"`
+		AverageIncome  int                     `json:"averageIncome"`
+		AverageExpense int                     `json:"averageExpense"`
+		AverageBalance int                     `json:"averageBalance"`
+		BestMonth      *monthlyReportResponse  `json:"bestMonth,omitempty"`
+		WorstMonth     *monthlyReportResponse  `json:"worstMonth,omitempty"`
+		Months         []monthlyReportResponse `json:"months"`
+	}
+	getNetWorthResponse struct {
+		Points []netWorthPointResponse `json:"points"`
+	}
+	netWorthPointResponse struct {
+		Date      time.Time `json:"date"`
+		Balance   int       `json:"balance"`
+		Estimated bool      `json:"estimated"`
+	}
+)
+
+// NewReports is create instance
+func NewReports(useCase usecases.Reports, clock core.Clock) Reports {
+	return &reports{useCase, clock}
+}
+
+func (t *reports) GetMonthlyReport(c echo.Context) error {
+	var err error
+	to := t.clock.Now()
+	if s := c.QueryParam("to"); s != "" {
+		to, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			return err
+		}
+	}
+	from := t.clock.AddMonths(&to, -11)
+	if s := c.QueryParam("from"); s != "" {
+		from, err = time.Parse("2006-01-02", s)
+		if err != nil {
+			return err
+		}
+	}
+	res, err := t.useCase.GetMonthlyReport(&usecases.GetMonthlyReportArgs{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, getMonthlyReportResponse{
+		Months: convertMonthlyReports(res.Months),
+	})
+}
+func convertMonthlyReports(months []usecases.MonthlyReportResult) []monthlyReportResponse {
+	x := make([]monthlyReportResponse, len(months))
+	for i, month := range months {
+		x[i] = monthlyReportResponse(month)
+	}
+	return x
+}
+func (t *reports) GetYearlyReport(c echo.Context) error {
+	var err error
+	to := t.clock.Now().Year()
+	if s := c.QueryParam("to"); s != "" {
+		to, err = strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+	}
+	from := to
+	if s := c.QueryParam("from"); s != "" {
+		from, err = strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+	}
+	res, err := t.useCase.GetYearlyReport(&usecases.GetYearlyReportArgs{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	years := make([]yearlyReportResponse, len(res.Years))
+	for i, year := range res.Years {
+		years[i] = yearlyReportResponse{
+			Year:           year.Year,
+			Income:         year.Income,
+			Expense:        year.Expense,
+			Balance:        year.Balance,
+			AverageIncome:  year.AverageIncome,
+			AverageExpense: year.AverageExpense,
+			AverageBalance: year.AverageBalance,
+			Months:         convertMonthlyReports(year.Months),
+		}
+		if year.BestMonth != nil {
+			best := monthlyReportResponse(*year.BestMonth)
+			years[i].BestMonth = &best
+		}
+		if year.WorstMonth != nil {
+			worst := monthlyReportResponse(*year.WorstMonth)
+			years[i].WorstMonth = &worst
+		}
+	}
+	return responses.WriteResponse(c, getYearlyReportResponse{Years: years})
+}
+func (t *reports) GetNetWorth(c echo.Context) error {
+	res, err := t.useCase.GetNetWorth(&usecases.GetNetWorthArgs{
+		Granularity: c.QueryParam("granularity"),
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	points := make([]netWorthPointResponse, len(res.Points))
+	for i, point := range res.Points {
+		points[i] = netWorthPointResponse(point)
+	}
+	return responses.WriteResponse(c, getNetWorthResponse{Points: points})
+}
@@ -3,6 +3,7 @@ package responses
 import (
 	"net/http"
 
+	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 
 	"github.com/labstack/echo"
@@ -19,17 +20,35 @@ type (
 	}
 )
 
+// statusCodes はエラーの種類に対応する HTTP ステータスです
+// 含まれない種類のエラーは 400 とします
+var statusCodes = map[string]int{
+	string(core.NotFound):        http.StatusNotFound,
+	string(application.NotFound): http.StatusNotFound,
+}
+
 // WriteErrorResponse はエラーをレスポンスボディーに書き込みます
 func WriteErrorResponse(c echo.Context, err error) error {
 	if cErr, ok := err.(core.Error); ok {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Errors: *cErr.GetErrorCodes(),
+		codes := *cErr.GetErrorCodes()
+		return c.JSON(statusCode(codes), ErrorResponse{
+			Errors: codes,
 		})
 	}
 	c.Logger().Error(err, c.Request())
 	return err
 }
 
+// statusCode はエラーの種類が 1 つの場合のみ、その種類に対応する HTTP ステータスを返します
+func statusCode(codes []string) int {
+	if len(codes) == 1 {
+		if status, ok := statusCodes[codes[0]]; ok {
+			return status
+		}
+	}
+	return http.StatusBadRequest
+}
+
 // WriteResponse は結果をレスポンスボディーに書き込みます
 func WriteResponse(c echo.Context, result interface{}) error {
 	return c.JSON(http.StatusOK, Response{
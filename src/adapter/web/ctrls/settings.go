@@ -0,0 +1,88 @@
+package ctrls
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+
+	"github.com/wakuwaku3/account-book.api/src/adapter/web/ctrls/responses"
+
+	"github.com/labstack/echo"
+)
+
+type (
+	settings struct {
+		useCase usecases.Settings
+	}
+	// Settings is SettingsController
+	Settings interface {
+		GetSettings(c echo.Context) error
+		UpdateSettings(c echo.Context) error
+		GetDigestSettings(c echo.Context) error
+		UpdateDigestSettings(c echo.Context) error
+	}
+	getSettingsResponse struct {
+		UserName        string    `json:"userName"`
+		Email           string    `json:"email"`
+		Culture         string    `json:"culture"`
+		MonthStartDay   int       `json:"monthStartDay"`
+		TimeZone        string    `json:"timeZone"`
+		CurrentMonthDay time.Time `json:"currentMonthDay"`
+	}
+	settingsRequest struct {
+		MonthStartDay int    `json:"monthStartDay"`
+		TimeZone      string `json:"timeZone"`
+	}
+	digestSettings struct {
+		WeeklyDigest   bool `json:"weeklyDigest"`
+		DigestWeekday  int  `json:"digestWeekday"`
+		MonthEndDigest bool `json:"monthEndDigest"`
+	}
+)
+
+// NewSettings is create instance
+func NewSettings(useCase usecases.Settings) Settings {
+	return &settings{useCase}
+}
+
+func (t *settings) GetSettings(c echo.Context) error {
+	res, err := t.useCase.GetSettings()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, getSettingsResponse(*res))
+}
+func (t *settings) UpdateSettings(c echo.Context) error {
+	request := new(settingsRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.UpdateSettings(&usecases.SettingsArgs{
+		MonthStartDay: request.MonthStartDay,
+		TimeZone:      request.TimeZone,
+	}); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
+func (t *settings) GetDigestSettings(c echo.Context) error {
+	res, err := t.useCase.GetDigestSettings()
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, digestSettings(*res))
+}
+func (t *settings) UpdateDigestSettings(c echo.Context) error {
+	request := new(digestSettings)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.UpdateDigestSettings(&usecases.DigestSettingsArgs{
+		WeeklyDigest:   request.WeeklyDigest,
+		DigestWeekday:  request.DigestWeekday,
+		MonthEndDigest: request.MonthEndDigest,
+	}); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
@@ -1,6 +1,7 @@
 package ctrls
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/wakuwaku3/account-book.api/src/application"
@@ -24,25 +25,64 @@ type (
 		Create(c echo.Context) error
 		Update(c echo.Context) error
 		Delete(c echo.Context) error
+		Batch(c echo.Context) error
+		GetDuplicates(c echo.Context) error
+		Merge(c echo.Context) error
 	}
 	getTransactionsResponse struct {
 		Transactions []getTransactionResponse `json:"transactions"`
 	}
 	getTransactionResponse struct {
-		TransactionID string    `json:"id"`
-		Amount        int       `json:"amount"`
-		Category      int       `json:"categoryId,string"`
-		Date          time.Time `json:"date"`
-		Notes         *string   `json:"notes,omitempty"`
-		Editable      bool      `json:"editable"`
+		TransactionID   string    `json:"id"`
+		Amount          int       `json:"amount"`
+		Category        int       `json:"categoryId,string"`
+		Uncategorized   bool      `json:"uncategorized"`
+		Date            time.Time `json:"date"`
+		Notes           *string   `json:"notes,omitempty"`
+		ImportReference *string   `json:"importReference,omitempty"`
+		ImportSource    *string   `json:"importSource,omitempty"`
+		PayeeID         *string   `json:"payeeId,omitempty"`
+		Tags            []string  `json:"tags,omitempty"`
+		Editable        bool      `json:"editable"`
 	}
 	transactionRequest struct {
-		Amount   *int    `json:"amount,omitempty"`
-		Category *int    `json:"categoryId,string,omitempty"`
-		Notes    *string `json:"notes,omitempty"`
+		Amount          *int     `json:"amount,omitempty"`
+		Category        *int     `json:"categoryId,string,omitempty"`
+		Notes           *string  `json:"notes,omitempty"`
+		ImportReference *string  `json:"importReference,omitempty"`
+		ImportSource    *string  `json:"importSource,omitempty"`
+		PayeeID         *string  `json:"payeeId,omitempty"`
+		Tags            []string `json:"tags,omitempty"`
 	}
 	createTransactionResponse struct {
-		TransactionID string `json:"id"`
+		TransactionID     string   `json:"id"`
+		PossibleDuplicate bool     `json:"possibleDuplicate"`
+		DuplicateIDs      []string `json:"duplicateIds,omitempty"`
+	}
+	getDuplicatesResponse struct {
+		Groups []getDuplicateGroupResponse `json:"groups"`
+	}
+	getDuplicateGroupResponse struct {
+		Transactions []getTransactionResponse `json:"transactions"`
+	}
+	mergeTransactionsRequest struct {
+		TransactionID string   `json:"id"`
+		MergeIDs      []string `json:"mergeIds"`
+	}
+	batchTransactionsRequest struct {
+		Operations []batchTransactionRequest `json:"operations"`
+	}
+	batchTransactionRequest struct {
+		Operation     string  `json:"operation"`
+		TransactionID *string `json:"id,omitempty"`
+		transactionRequest
+	}
+	batchTransactionsResponse struct {
+		Results []batchTransactionResponse `json:"results"`
+	}
+	batchTransactionResponse struct {
+		TransactionID *string  `json:"id,omitempty"`
+		Errors        []string `json:"errors,omitempty"`
 	}
 )
 
@@ -80,12 +120,17 @@ func convertTransactions(transactions []usecases.GetTransactionResult) []getTran
 }
 func convertTransaction(transaction usecases.GetTransactionResult) getTransactionResponse {
 	return getTransactionResponse{
-		TransactionID: transaction.TransactionID,
-		Amount:        transaction.Amount,
-		Category:      transaction.Category,
-		Date:          transaction.Date,
-		Notes:         transaction.Notes,
-		Editable:      transaction.Editable,
+		TransactionID:   transaction.TransactionID,
+		Amount:          transaction.Amount,
+		Category:        transaction.Category,
+		Uncategorized:   transaction.Uncategorized,
+		Date:            transaction.Date,
+		Notes:           transaction.Notes,
+		ImportReference: transaction.ImportReference,
+		ImportSource:    transaction.ImportSource,
+		PayeeID:         transaction.PayeeID,
+		Tags:            transaction.Tags,
+		Editable:        transaction.Editable,
 	}
 }
 func (t *transactions) GetTransaction(c echo.Context) error {
@@ -109,14 +154,20 @@ func (t *transactions) Create(c echo.Context) error {
 		return responses.WriteErrorResponse(c, err)
 	}
 	return responses.WriteResponse(c, createTransactionResponse{
-		TransactionID: res.TransactionID,
+		TransactionID:     res.TransactionID,
+		PossibleDuplicate: res.PossibleDuplicate,
+		DuplicateIDs:      res.DuplicateTransactionIDs,
 	})
 }
 func (t *transactionRequest) convert() *usecases.TransactionArgs {
 	return &usecases.TransactionArgs{
-		Amount:   t.Amount,
-		Category: t.Category,
-		Notes:    t.Notes,
+		Amount:          t.Amount,
+		Category:        t.Category,
+		Notes:           t.Notes,
+		ImportReference: t.ImportReference,
+		ImportSource:    t.ImportSource,
+		PayeeID:         t.PayeeID,
+		Tags:            t.Tags,
 	}
 }
 
@@ -144,3 +195,79 @@ func (t *transactions) Delete(c echo.Context) error {
 	}
 	return responses.WriteEmptyResponse(c)
 }
+func (t *transactions) Batch(c echo.Context) error {
+	request := new(batchTransactionsRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	res, err := t.useCase.Batch(request.convert())
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteResponse(c, convertBatchTransactions(res))
+}
+func (t *batchTransactionsRequest) convert() *usecases.BatchTransactionsArgs {
+	operations := make([]usecases.BatchTransactionArgs, len(t.Operations))
+	for i, operation := range t.Operations {
+		operations[i] = usecases.BatchTransactionArgs{
+			Operation:       operation.Operation,
+			TransactionID:   operation.TransactionID,
+			TransactionArgs: *operation.transactionRequest.convert(),
+		}
+	}
+	return &usecases.BatchTransactionsArgs{Operations: operations}
+}
+func convertBatchTransactions(t *usecases.BatchTransactionsResult) batchTransactionsResponse {
+	results := make([]batchTransactionResponse, len(t.Results))
+	for i, result := range t.Results {
+		results[i] = batchTransactionResponse{TransactionID: result.TransactionID}
+		if result.Error != nil {
+			results[i].Errors = *result.Error.GetErrorCodes()
+		}
+	}
+	return batchTransactionsResponse{Results: results}
+}
+func (t *transactions) GetDuplicates(c echo.Context) error {
+	var err error
+	selectedMonth := t.clock.Now()
+	if month := c.QueryParam("month"); month != "" {
+		selectedMonth, err = time.Parse("2006-01-02", month)
+		if err != nil {
+			return err
+		}
+	}
+	days := 0
+	if d := c.QueryParam("days"); d != "" {
+		days, err = strconv.Atoi(d)
+		if err != nil {
+			return responses.WriteErrorResponse(c, core.NewError(application.InValidDuplicateDays))
+		}
+	}
+	res, err := t.useCase.GetDuplicates(&usecases.GetDuplicatesArgs{
+		SelectedMonth: selectedMonth,
+		Days:          days,
+	})
+	if err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	groups := make([]getDuplicateGroupResponse, len(res.Groups))
+	for i, group := range res.Groups {
+		groups[i] = getDuplicateGroupResponse{
+			Transactions: convertTransactions(group.Transactions),
+		}
+	}
+	return responses.WriteResponse(c, getDuplicatesResponse{Groups: groups})
+}
+func (t *transactions) Merge(c echo.Context) error {
+	request := new(mergeTransactionsRequest)
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	if err := t.useCase.Merge(&usecases.MergeTransactionsArgs{
+		TransactionID:       request.TransactionID,
+		MergeTransactionIDs: request.MergeIDs,
+	}); err != nil {
+		return responses.WriteErrorResponse(c, err)
+	}
+	return responses.WriteEmptyResponse(c)
+}
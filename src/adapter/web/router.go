@@ -86,6 +86,36 @@ func (web *web) setRoute() *web {
 		})
 	})
 
+	// settings
+	// GET
+	auth.GET("/settings", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Settings) error {
+			return controller.GetSettings(c)
+		})
+	})
+	// PUT
+	auth.PUT("/settings", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Settings) error {
+			return controller.UpdateSettings(c)
+		})
+	})
+	// GET
+	auth.GET("/settings/digest", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Settings) error {
+			return controller.GetDigestSettings(c)
+		})
+	})
+	// PUT
+	auth.PUT("/settings/digest", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Settings) error {
+			return controller.UpdateDigestSettings(c)
+		})
+	})
+
 	// transactions
 	// GET
 	auth.GET("/transactions", func(c echo.Context) error {
@@ -95,6 +125,20 @@ func (web *web) setRoute() *web {
 		})
 	})
 	// GET
+	auth.GET("/transactions/duplicates", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Transactions) error {
+			return controller.GetDuplicates(c)
+		})
+	})
+	// POST
+	auth.POST("/transactions/duplicates/merge", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Transactions) error {
+			return controller.Merge(c)
+		})
+	})
+	// GET
 	auth.GET("/transactions/:id", func(c echo.Context) error {
 		container := GetContainer(c)
 		return container.Invoke(func(controller ctrls.Transactions) error {
@@ -108,6 +152,13 @@ func (web *web) setRoute() *web {
 			return controller.Create(c)
 		})
 	})
+	// POST
+	auth.POST("/transactions/batch", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Transactions) error {
+			return controller.Batch(c)
+		})
+	})
 	// PUT
 	auth.PUT("/transactions/:id", func(c echo.Context) error {
 		container := GetContainer(c)
@@ -123,6 +174,117 @@ func (web *web) setRoute() *web {
 		})
 	})
 
+	// attachments
+	// GET
+	auth.GET("/transactions/:id/attachments", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Attachments) error {
+			return controller.GetAttachments(c)
+		})
+	})
+	// GET
+	auth.GET("/transactions/:id/attachments/:attachmentId", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Attachments) error {
+			return controller.GetAttachment(c)
+		})
+	})
+	// POST
+	auth.POST("/transactions/:id/attachments", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Attachments) error {
+			return controller.Upload(c)
+		})
+	})
+	// DELETE
+	auth.DELETE("/transactions/:id/attachments/:attachmentId", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Attachments) error {
+			return controller.Delete(c)
+		})
+	})
+
+	// payees
+	// GET
+	auth.GET("/payees", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Payees) error {
+			return controller.GetPayees(c)
+		})
+	})
+	// GET
+	auth.GET("/payees/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Payees) error {
+			return controller.GetPayee(c)
+		})
+	})
+	// POST
+	auth.POST("/payees", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Payees) error {
+			return controller.Create(c)
+		})
+	})
+	// PUT
+	auth.PUT("/payees/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Payees) error {
+			return controller.Update(c)
+		})
+	})
+	// DELETE
+	auth.DELETE("/payees/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Payees) error {
+			return controller.Delete(c)
+		})
+	})
+
+	// categorization rules
+	// GET
+	auth.GET("/categorization/rules", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.GetCategorizationRules(c)
+		})
+	})
+	// GET
+	auth.GET("/categorization/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.GetCategorizationRule(c)
+		})
+	})
+	// POST
+	auth.POST("/categorization/rules", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.Create(c)
+		})
+	})
+	// POST
+	auth.POST("/categorization/rules/apply", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.Apply(c)
+		})
+	})
+	// PUT
+	auth.PUT("/categorization/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.Update(c)
+		})
+	})
+	// DELETE
+	auth.DELETE("/categorization/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.CategorizationRules) error {
+			return controller.Delete(c)
+		})
+	})
+
 	// plans
 	// GET
 	auth.GET("/plans", func(c echo.Context) error {
@@ -139,6 +301,20 @@ func (web *web) setRoute() *web {
 		})
 	})
 	// POST
+	auth.POST("/plans/:id/restore", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Plans) error {
+			return controller.Restore(c)
+		})
+	})
+	// POST
+	auth.POST("/plans/recurrences/migrate", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Plans) error {
+			return controller.MigrateRecurrences(c)
+		})
+	})
+	// POST
 	auth.POST("/plans", func(c echo.Context) error {
 		container := GetContainer(c)
 		return container.Invoke(func(controller ctrls.Plans) error {
@@ -160,6 +336,50 @@ func (web *web) setRoute() *web {
 		})
 	})
 
+	// budgets
+	// GET
+	auth.GET("/budgets", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.GetBudgets(c)
+		})
+	})
+	// GET
+	auth.GET("/budgets/report", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.GetBudgetReport(c)
+		})
+	})
+	// GET
+	auth.GET("/budgets/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.GetBudget(c)
+		})
+	})
+	// POST
+	auth.POST("/budgets", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.Create(c)
+		})
+	})
+	// PUT
+	auth.PUT("/budgets/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.Update(c)
+		})
+	})
+	// DELETE
+	auth.DELETE("/budgets/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Budgets) error {
+			return controller.Delete(c)
+		})
+	})
+
 	// Dashboard
 	// GET
 	auth.GET("/dashboard", func(c echo.Context) error {
@@ -168,6 +388,13 @@ func (web *web) setRoute() *web {
 			return controller.GetDashboard(c)
 		})
 	})
+	// ApproveThrough
+	auth.POST("/dashboard/approve-through", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Dashboard) error {
+			return controller.ApproveThrough(c)
+		})
+	})
 	// Approve
 	auth.POST("/dashboard/:id", func(c echo.Context) error {
 		container := GetContainer(c)
@@ -182,14 +409,62 @@ func (web *web) setRoute() *web {
 			return controller.CancelApprove(c)
 		})
 	})
+	// GetAdjustments
+	auth.GET("/dashboard/:id/adjustments", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Dashboard) error {
+			return controller.GetAdjustments(c)
+		})
+	})
 	// AdjustBalance
-	auth.DELETE("/dashboard/:id", func(c echo.Context) error {
+	auth.POST("/dashboard/:id/adjustments", func(c echo.Context) error {
 		container := GetContainer(c)
 		return container.Invoke(func(controller ctrls.Dashboard) error {
 			return controller.AdjustBalance(c)
 		})
 	})
 
+	// Forecast
+	// GET
+	auth.GET("/forecast", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Forecast) error {
+			return controller.GetForecast(c)
+		})
+	})
+
+	// Reports
+	// GET
+	auth.GET("/reports/monthly", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Reports) error {
+			return controller.GetMonthlyReport(c)
+		})
+	})
+	// GET
+	auth.GET("/reports/yearly", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Reports) error {
+			return controller.GetYearlyReport(c)
+		})
+	})
+	// GET
+	auth.GET("/reports/net-worth", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Reports) error {
+			return controller.GetNetWorth(c)
+		})
+	})
+
+	// Aggregates
+	// POST
+	auth.POST("/aggregates/repair", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Aggregates) error {
+			return controller.Repair(c)
+		})
+	})
+
 	// Actual
 	// GET
 	auth.GET("/actual", func(c echo.Context) error {
@@ -207,12 +482,87 @@ func (web *web) setRoute() *web {
 	})
 
 	// Notification Rules
+	// GET
 	auth.GET("/notification/rules", func(c echo.Context) error {
 		container := GetContainer(c)
-		return container.Invoke(func(controller ctrls.Actual) error {
+		return container.Invoke(func(controller ctrls.NotificationRules) error {
+			return controller.GetNotificationRules(c)
+		})
+	})
+	// GET
+	auth.GET("/notification/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationRules) error {
+			return controller.GetNotificationRule(c)
+		})
+	})
+	// POST
+	auth.POST("/notification/rules", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationRules) error {
+			return controller.Create(c)
+		})
+	})
+	// PUT
+	auth.PUT("/notification/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationRules) error {
+			return controller.Update(c)
+		})
+	})
+	// DELETE
+	auth.DELETE("/notification/rules/:id", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationRules) error {
+			return controller.Delete(c)
+		})
+	})
+
+	// Notification Channels
+	// GET
+	auth.GET("/notification/channels", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationChannels) error {
 			return controller.Get(c)
 		})
 	})
+	// PUT
+	auth.PUT("/notification/channels", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.NotificationChannels) error {
+			return controller.Update(c)
+		})
+	})
+
+	// Notifications
+	// GET
+	auth.GET("/notifications", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Notifications) error {
+			return controller.GetNotifications(c)
+		})
+	})
+	// GET
+	auth.GET("/notifications/unread-count", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Notifications) error {
+			return controller.GetUnreadCount(c)
+		})
+	})
+	// POST
+	auth.POST("/notifications/read-all", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Notifications) error {
+			return controller.ReadAll(c)
+		})
+	})
+	// POST
+	auth.POST("/notifications/:id/read", func(c echo.Context) error {
+		container := GetContainer(c)
+		return container.Invoke(func(controller ctrls.Notifications) error {
+			return controller.Read(c)
+		})
+	})
 
 	return web
 }
@@ -0,0 +1,13 @@
+package application
+
+import "path"
+
+// AttachmentKey は添付ファイルを BlobStore に保存する際のキーです
+func AttachmentKey(transactionID string, attachmentID string) string {
+	return path.Join("transactions", transactionID, "attachments", attachmentID)
+}
+
+// ThumbnailKey は添付ファイルのサムネイルを BlobStore に保存する際のキーです
+func ThumbnailKey(transactionID string, attachmentID string) string {
+	return AttachmentKey(transactionID, attachmentID) + "-thumbnail"
+}
@@ -47,4 +47,62 @@ const (
 	RequiredName core.ErrorCode = "00021"
 	// InValidCulture :不正なカルチャーです。
 	InValidCulture core.ErrorCode = "00022"
+	// TooManyOperations :一括操作の件数が上限を超えています。
+	TooManyOperations core.ErrorCode = "00023"
+	// InValidOperation :不正な操作です。
+	InValidOperation core.ErrorCode = "00024"
+	// RequiredFile :ファイルは必須です。
+	RequiredFile core.ErrorCode = "00025"
+	// NotSupportedContentType :サポートしていないファイル形式です。
+	NotSupportedContentType core.ErrorCode = "00026"
+	// TooLargeFile :ファイルサイズが上限を超えています。
+	TooLargeFile core.ErrorCode = "00027"
+	// RequiredMergeTransactions :統合する取引は必須です。
+	RequiredMergeTransactions core.ErrorCode = "00028"
+	// NotDuplicateTransaction :重複していない取引は統合できません。
+	NotDuplicateTransaction core.ErrorCode = "00029"
+	// RequiredRuleCondition :ルールの条件は必須です。
+	RequiredRuleCondition core.ErrorCode = "00030"
+	// RequiredRuleAction :ルールの設定内容は必須です。
+	RequiredRuleAction core.ErrorCode = "00031"
+	// InValidAmountRange :金額の範囲が不正です。
+	InValidAmountRange core.ErrorCode = "00032"
+	// InValidFrequency :不正な繰り返し頻度です。
+	InValidFrequency core.ErrorCode = "00033"
+	// InValidRecurrence :不正な繰り返し規則です。
+	InValidRecurrence core.ErrorCode = "00034"
+	// InValidForecastMonths :予測する月数が不正です。
+	InValidForecastMonths core.ErrorCode = "00035"
+	// MoreThanZeroAmount :金額は0より大きい必要があります。
+	MoreThanZeroAmount core.ErrorCode = "00036"
+	// RequiredMonth :月は必須です。
+	RequiredMonth core.ErrorCode = "00037"
+	// DuplicateBudget :同じカテゴリと月の予算が既に存在します。
+	DuplicateBudget core.ErrorCode = "00038"
+	// NotDeleted :削除されていません。
+	NotDeleted core.ErrorCode = "00039"
+	// ConflictsWithClosedMonth :締め済みの月と矛盾するため復元できません。
+	ConflictsWithClosedMonth core.ErrorCode = "00040"
+	// TooLongPeriod :期間が上限を超えています。
+	TooLongPeriod core.ErrorCode = "00041"
+	// NotEndedMonth :終了していない月は締められません。
+	NotEndedMonth core.ErrorCode = "00042"
+	// RequiredReason :理由は必須です。
+	RequiredReason core.ErrorCode = "00043"
+	// InValidGranularity :集計の単位が不正です。
+	InValidGranularity core.ErrorCode = "00044"
+	// InValidMonthStartDay :月の開始日が不正です。
+	InValidMonthStartDay core.ErrorCode = "00045"
+	// InValidTimeZone :タイムゾーンが不正です。
+	InValidTimeZone core.ErrorCode = "00047"
+	// InValidLimit :取得件数が不正です。
+	InValidLimit core.ErrorCode = "00048"
+	// RequiredMetrics :メトリクスは必須です。
+	RequiredMetrics core.ErrorCode = "00049"
+	// InValidWeekday :曜日が不正です。
+	InValidWeekday core.ErrorCode = "00050"
+	// DuplicateTransactionID :同じ取引が複数指定されています。
+	DuplicateTransactionID core.ErrorCode = "00051"
+	// InValidDuplicateDays :重複とみなす日数が不正です。
+	InValidDuplicateDays core.ErrorCode = "00053"
 )
@@ -15,6 +15,9 @@ type (
 		GetPasswordHashedKey() *[]byte
 		GetJwtSecret() *[]byte
 		GetSendGridAPIKey() *string
+		GetAlertTemplateID() *string
+		GetWeeklyDigestTemplateID() *string
+		GetMonthEndDigestTemplateID() *string
 		GetFrontEndURL() *string
 		IsProduction() bool
 		GetAllowOrigins() *[]string
@@ -22,11 +25,22 @@ type (
 		GetAwsSecretAccessKey() *string
 		GetAwsTopics() *map[core.EventName]AwsTopicArn
 		GetAwsQueues() *map[core.QueueName]AwsQueueURL
+		GetBlobStorePath() *string
 	}
 	// AwsTopicArn は Topic の Arn です
 	AwsTopicArn string
 	// AwsQueueURL は Queue の URL です
 	AwsQueueURL string
+	// BlobStore はファイルの保存先です
+	BlobStore interface {
+		Put(key string, data []byte) error
+		Get(key string) (*[]byte, error)
+		Delete(key string) error
+	}
+	// Thumbnailer はサムネイル画像を生成します
+	Thumbnailer interface {
+		Create(contentType string, data []byte) (*[]byte, error)
+	}
 	// Crypt はハッシュ化のサービスです
 	Crypt interface {
 		Hash(text *string) *string
@@ -43,6 +57,7 @@ type (
 		UserName     string
 		Email        string
 		Culture      string
+		TimeZone     string
 		UseStartDate time.Time
 	}
 	// JwtRefreshClaims はRefreshTokenにうめこまれます
@@ -55,6 +70,10 @@ type (
 	UsersRepository interface {
 		Get(userID *string) (*models.User, error)
 		GetByAuth() (*models.User, error)
+		ModifyMonthBoundary(modify func(boundary *models.MonthBoundary)) error
+		UpdateDigestSettings(model *models.User) error
+		UpdateDigestSentAt(model *models.User) error
+		GetDigestSubscribers() (*[]models.User, error)
 	}
 	// AccountsRepository はアカウントのリポジトリです
 	AccountsRepository interface {
@@ -98,17 +117,86 @@ type (
 		Email string
 		Token string
 	}
+	// WeeklyDigestMail は週次のまとめメール送信サービスです
+	WeeklyDigestMail interface {
+		Send(args *WeeklyDigestMailSendArgs) error
+	}
+	// WeeklyDigestMailSendArgs は週次のまとめメール送信用パラメータです
+	WeeklyDigestMailSendArgs struct {
+		Email string
+		// Start から End の前日までの 1 週間の支出を前月の同じ週と比べます
+		Start           time.Time
+		End             time.Time
+		Expense         int
+		PreviousExpense int
+	}
+	// MonthEndDigestMail は月末のお知らせメール送信サービスです
+	MonthEndDigestMail interface {
+		Send(args *MonthEndDigestMailSendArgs) error
+	}
+	// MonthEndDigestMailSendArgs は月末のお知らせメール送信用パラメータです
+	MonthEndDigestMailSendArgs struct {
+		Email            string
+		Month            time.Time
+		MissingPlanNames []string
+		CanApprove       bool
+	}
 	// TransactionsRepository は取引のリポジトリです
 	TransactionsRepository interface {
 		Get(id *string) (*models.Transaction, error)
 		GetByMonth(month *time.Time) (*[]models.Transaction, error)
+		GetByDateRange(start *time.Time, end *time.Time) (*[]models.Transaction, error)
 		Create(model *models.Transaction) (*string, error)
 		Update(id *string, model *models.Transaction) error
 		Delete(id *string) error
+		Batch(items *[]TransactionsBatchItem) (*[]string, error)
+		GetAttachments(transactionID *string) (*[]models.Attachment, error)
+		GetAttachment(transactionID *string, id *string) (*models.Attachment, error)
+		CreateAttachment(transactionID *string, model *models.Attachment) (*string, error)
+		DeleteAttachment(transactionID *string, id *string) error
+	}
+	// AggregatesRepository は締め前の月の取引の集計のリポジトリです
+	// 集計は取引の作成・更新・削除と同じトランザクションで更新されます
+	AggregatesRepository interface {
+		GetByMonth(month *time.Time) (*models.Aggregate, error)
+		Rebuild(month *time.Time) (*models.Aggregate, error)
+	}
+	// BatchOperation は一括操作の種類です
+	BatchOperation string
+	// TransactionsBatchItem は取引の一括操作の1件です
+	TransactionsBatchItem struct {
+		Operation     BatchOperation
+		TransactionID *string
+		Model         *models.Transaction
+	}
+	// PayeesRepository は支払先のリポジトリです
+	PayeesRepository interface {
+		Get() (*[]models.Payee, error)
+		GetByID(id *string) (*models.Payee, error)
+		Create(model *models.Payee) (*string, error)
+		Update(id *string, model *models.Payee) error
+		Delete(id *string) error
+	}
+	// CategorizationRulesRepository は自動分類ルールのリポジトリです
+	CategorizationRulesRepository interface {
+		Get() (*[]models.CategorizationRule, error)
+		GetByID(id *string) (*models.CategorizationRule, error)
+		Create(model *models.CategorizationRule) (*string, error)
+		Update(id *string, model *models.CategorizationRule) error
+		Delete(id *string) error
+	}
+	// BudgetsRepository は予算のリポジトリです
+	BudgetsRepository interface {
+		Get() (*[]models.Budget, error)
+		GetByID(id *string) (*models.Budget, error)
+		Create(model *models.Budget) (*string, error)
+		Update(id *string, model *models.Budget) error
+		Delete(id *string) error
 	}
 	// PlansRepository は計画のリポジトリです
 	PlansRepository interface {
 		Get() (*[]models.Plan, error)
+		GetIncludeDeleted() (*[]models.Plan, error)
 		GetByMonth(month *time.Time) (*[]models.Plan, error)
 		GetByID(id *string) (*models.Plan, error)
 		Create(model *models.Plan) (*string, error)
@@ -120,14 +208,31 @@ type (
 		ExistsClosedNext(id *string) error
 		GetLatestClosedDashboard() (*models.Dashboard, error)
 		GetOldestOpenDashboard() (*models.Dashboard, error)
+		GetClosedDashboards() (*[]models.Dashboard, error)
+		GetDaily(dashboardID *string) (*[]models.Daily, error)
 		GetByMonth(month *time.Time) (*models.Dashboard, error)
 		Create(month *time.Time) (*string, error)
 		Approve(model *models.Dashboard) error
+		ApproveAll(dashboards *[]models.Dashboard) error
 		CancelApprove(model *models.Dashboard) error
+		CancelApproveCascade(id *string, reopen func(model *models.Dashboard)) (*[]models.Dashboard, error)
 		GetActual(dashboardID *string, id *string) (*models.Actual, error)
 		ExistsActual(dashboardID *string, planID *string) (*string, error)
 		CreateActual(dashboardID *string, model *models.Actual) (*string, error)
 		UpdateActual(dashboardID *string, id *string, model *models.Actual) error
-		AdjustBalance(id *string, balance int) error
+		GetAdjustments(dashboardID *string) (*[]models.Adjustment, error)
+		AddAdjustment(dashboardID *string, model *models.Adjustment) (*string, int, error)
 	}
 )
+
+const (
+	// BatchCreate は作成です
+	BatchCreate BatchOperation = "create"
+	// BatchUpdate は更新です
+	BatchUpdate BatchOperation = "update"
+	// BatchDelete は削除です
+	BatchDelete BatchOperation = "delete"
+	// MaxTransactionsBatchSize は TransactionsRepository.Batch で 1 回に反映できる上限件数です
+	// 月毎の集計の書き込みを含めて Firestore の 1 回の書き込みの上限(500)に収まる件数とします
+	MaxTransactionsBatchSize = 250
+)
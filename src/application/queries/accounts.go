@@ -36,6 +36,7 @@ func (t *accounts) GetSignInInfo(email *string) (*usecases.SignInInfo, error) {
 			UserID:       account.UserID,
 			UserName:     user.UserName,
 			Culture:      user.Culture,
+			TimeZone:     user.TimeZone,
 			UseStartDate: user.UseStartDate,
 		},
 		JwtRefreshClaims: application.JwtRefreshClaims{
@@ -62,6 +63,7 @@ func (t *accounts) GetRefreshInfo(email *string) (*usecases.RefreshInfo, error)
 			UserID:       account.UserID,
 			UserName:     user.UserName,
 			Culture:      user.Culture,
+			TimeZone:     user.TimeZone,
 			UseStartDate: user.UseStartDate,
 		},
 		JwtRefreshClaims: application.JwtRefreshClaims{
@@ -104,6 +106,7 @@ func (t *accounts) GetResetPasswordInfo(passwordResetToken *string) (*usecases.R
 			UserID:       account.UserID,
 			UserName:     user.UserName,
 			Culture:      user.Culture,
+			TimeZone:     user.TimeZone,
 			UseStartDate: user.UseStartDate,
 		},
 		JwtRefreshClaims: application.JwtRefreshClaims{
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"github.com/wakuwaku3/account-book.api/src/application"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 
 	"github.com/wakuwaku3/account-book.api/src/application/usecases"
@@ -30,6 +31,9 @@ func (t *actual) Get(args *usecases.GetActualArgs) (
 	if err != nil {
 		return nil, err
 	}
+	if args.SelectedMonth != nil {
+		accountbook.ApplyRevision(plan, *args.SelectedMonth)
+	}
 
 	result := &usecases.GetActualResult{
 		PlanAmount: plan.PlanAmount,
@@ -56,6 +60,9 @@ func (t *actual) GetActualInfo(key *models.ActualKey) (*usecases.ActualInfo, err
 	if err != nil {
 		return nil, err
 	}
+	if key.SelectedMonth != nil {
+		accountbook.ApplyRevision(plan, *key.SelectedMonth)
+	}
 	return &usecases.ActualInfo{
 		PlanID:        plan.PlanID,
 		PlanName:      plan.PlanName,
@@ -0,0 +1,71 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type attachments struct {
+	repos     application.TransactionsRepository
+	blobStore application.BlobStore
+}
+
+// NewAttachments はインスタンスを生成します
+func NewAttachments(
+	repos application.TransactionsRepository,
+	blobStore application.BlobStore,
+) usecases.AttachmentsQuery {
+	return &attachments{
+		repos,
+		blobStore,
+	}
+}
+func (t *attachments) GetAttachments(transactionID *string) (*usecases.GetAttachmentsResult, error) {
+	records, err := t.repos.GetAttachments(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]usecases.AttachmentResult, len(*records))
+	for i, record := range *records {
+		r := &record
+		attachments[i] = *convertAttachment(r)
+	}
+	return &usecases.GetAttachmentsResult{Attachments: attachments}, nil
+}
+func (t *attachments) GetAttachment(args *usecases.GetAttachmentArgs) (*usecases.GetAttachmentResult, error) {
+	model, err := t.repos.GetAttachment(&args.TransactionID, &args.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	key := application.AttachmentKey(args.TransactionID, args.AttachmentID)
+	contentType := model.ContentType
+	if args.Thumbnail {
+		if !model.HasThumbnail {
+			return nil, core.NewError(application.NotFound)
+		}
+		key = application.ThumbnailKey(args.TransactionID, args.AttachmentID)
+		contentType = "image/jpeg"
+	}
+	data, err := t.blobStore.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return &usecases.GetAttachmentResult{
+		FileName:    model.FileName,
+		ContentType: contentType,
+		Data:        *data,
+	}, nil
+}
+func convertAttachment(model *models.Attachment) *usecases.AttachmentResult {
+	return &usecases.AttachmentResult{
+		AttachmentID: model.AttachmentID,
+		FileName:     model.FileName,
+		ContentType:  model.ContentType,
+		Size:         model.Size,
+		HasThumbnail: model.HasThumbnail,
+		CreatedAt:    model.CreatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package queries
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type budgets struct {
+	repos             application.BudgetsRepository
+	transactionsRepos application.TransactionsRepository
+	clock             core.Clock
+}
+
+// NewBudgets はインスタンスを生成します
+func NewBudgets(
+	repos application.BudgetsRepository,
+	transactionsRepos application.TransactionsRepository,
+	clock core.Clock,
+) usecases.BudgetsQuery {
+	return &budgets{
+		repos,
+		transactionsRepos,
+		clock,
+	}
+}
+func (t *budgets) GetBudgets() (*usecases.GetBudgetsResult, error) {
+	records, err := t.repos.Get()
+	if err != nil {
+		return nil, err
+	}
+	budgets := make([]usecases.GetBudgetResult, len(*records))
+	for i, record := range *records {
+		r := &record
+		budgets[i] = *convertBudget(r)
+	}
+	return &usecases.GetBudgetsResult{Budgets: budgets}, nil
+}
+func (t *budgets) GetBudget(id *string) (*usecases.GetBudgetResult, error) {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return convertBudget(model), nil
+}
+func convertBudget(t *models.Budget) *usecases.GetBudgetResult {
+	return &usecases.GetBudgetResult{
+		BudgetID: t.BudgetID,
+		Category: t.Category,
+		Month:    t.Month,
+		Amount:   t.Amount,
+		Rollover: t.Rollover,
+	}
+}
+func (t *budgets) GetBudgetReport(args *usecases.GetBudgetReportArgs) (*usecases.GetBudgetReportResult, error) {
+	month := t.clock.GetMonthStartDay(&args.SelectedMonth)
+	budgets, err := t.repos.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	// 繰越の計算のため、遡る月の取引もまとめて取得します
+	start := t.clock.AddMonths(&month, -accountbook.MaxRolloverMonths)
+	end := t.clock.AddMonths(&month, 1)
+	transactions, err := t.transactionsRepos.GetByDateRange(&start, &end)
+	if err != nil {
+		return nil, err
+	}
+	monthly := map[time.Time][]models.Transaction{}
+	for _, transaction := range *transactions {
+		key := t.clock.GetMonthStartDay(&transaction.Date)
+		monthly[key] = append(monthly[key], transaction)
+	}
+	spent := accountbook.SpentByCategory(monthly[month])
+	previousMonths := make([]time.Time, accountbook.MaxRolloverMonths)
+	previousSpent := make([]map[int]int, accountbook.MaxRolloverMonths)
+	for i := range previousMonths {
+		previousMonths[i] = t.clock.AddMonths(&month, -(i + 1))
+		previousSpent[i] = accountbook.SpentByCategory(monthly[previousMonths[i]])
+	}
+
+	categories := map[int]bool{}
+	for category := range spent {
+		categories[category] = true
+	}
+	for _, budget := range *budgets {
+		if !budget.Month.After(month) {
+			categories[budget.Category] = true
+		}
+	}
+	result := &usecases.GetBudgetReportResult{
+		SelectedMonth: month,
+		Categories:    make([]usecases.BudgetReportCategoryResult, 0, len(categories)),
+	}
+	for category := range categories {
+		x := usecases.BudgetReportCategoryResult{
+			Category: category,
+			Spent:    spent[category],
+		}
+		if budget := accountbook.EffectiveBudget(*budgets, category, month); budget != nil {
+			budgetID := budget.BudgetID
+			x.BudgetID = &budgetID
+			x.Budgeted = budget.Amount
+			if budget.Rollover {
+				x.Rollover = accountbook.Rollover(*budgets, category, previousMonths, previousSpent)
+			}
+		}
+		x.Remaining = x.Budgeted + x.Rollover - x.Spent
+		result.Categories = append(result.Categories, x)
+	}
+	sort.SliceStable(result.Categories, func(i, j int) bool {
+		return result.Categories[i].Category < result.Categories[j].Category
+	})
+	return result, nil
+}
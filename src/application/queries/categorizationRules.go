@@ -0,0 +1,57 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type categorizationRules struct {
+	repos application.CategorizationRulesRepository
+}
+
+// NewCategorizationRules はインスタンスを生成します
+func NewCategorizationRules(
+	repos application.CategorizationRulesRepository,
+) usecases.CategorizationRulesQuery {
+	return &categorizationRules{
+		repos,
+	}
+}
+func (t *categorizationRules) GetCategorizationRules() (*usecases.GetCategorizationRulesResult, error) {
+	records, err := t.repos.Get()
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]usecases.GetCategorizationRuleResult, len(*records))
+	for i, record := range *records {
+		r := &record
+		rules[i] = *convertCategorizationRule(r)
+	}
+	return &usecases.GetCategorizationRulesResult{CategorizationRules: rules}, nil
+}
+func (t *categorizationRules) GetCategorizationRule(id *string) (*usecases.GetCategorizationRuleResult, error) {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return convertCategorizationRule(model), nil
+}
+func convertCategorizationRule(t *models.CategorizationRule) *usecases.GetCategorizationRuleResult {
+	return &usecases.GetCategorizationRuleResult{
+		RuleID: t.RuleID,
+		CategorizationRuleArgs: usecases.CategorizationRuleArgs{
+			RuleName:     t.RuleName,
+			Priority:     t.Priority,
+			NotesPattern: t.NotesPattern,
+			PayeePattern: t.PayeePattern,
+			MinAmount:    t.MinAmount,
+			MaxAmount:    t.MaxAmount,
+			ImportSource: t.ImportSource,
+			Category:     t.Category,
+			Tags:         t.Tags,
+			PayeeID:      t.PayeeID,
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 
 	"github.com/wakuwaku3/account-book.api/src/application/usecases"
 )
@@ -16,6 +17,7 @@ import (
 type dashboard struct {
 	repos             application.DashboardRepository
 	transactionsRepos application.TransactionsRepository
+	aggregatesRepos   application.AggregatesRepository
 	plansRepos        application.PlansRepository
 	clock             core.Clock
 }
@@ -24,12 +26,14 @@ type dashboard struct {
 func NewDashboard(
 	repos application.DashboardRepository,
 	transactionsRepos application.TransactionsRepository,
+	aggregatesRepos application.AggregatesRepository,
 	plansRepos application.PlansRepository,
 	clock core.Clock,
 ) usecases.DashboardQuery {
 	return &dashboard{
 		repos,
 		transactionsRepos,
+		aggregatesRepos,
 		plansRepos,
 		clock,
 	}
@@ -64,7 +68,7 @@ func (t *dashboard) GetSummary(args *usecases.GetDashboardArgs) (*usecases.GetDa
 	if selectedMonth == nil {
 		m := t.clock.GetMonthStartDay(nil)
 		if previousDashboard != nil {
-			m = previousDashboard.Date.AddDate(0, 1, 0)
+			m = t.clock.AddMonths(&previousDashboard.Date, 1)
 		}
 		selectedMonth = &m
 	}
@@ -94,7 +98,7 @@ func (t *dashboard) canApprove(result *usecases.GetDashboardResult, allInputPlan
 }
 func (t *dashboard) getPreviousDashboard(selectedMonth *time.Time) (*models.Dashboard, error) {
 	if selectedMonth != nil {
-		previousMonth := selectedMonth.AddDate(0, -1, 0)
+		previousMonth := t.clock.AddMonths(selectedMonth, -1)
 		return t.repos.GetByMonth(&previousMonth)
 	}
 	return t.repos.GetLatestClosedDashboard()
@@ -148,7 +152,7 @@ func (t *dashboard) getSummaryByMonth(selectedMonth *time.Time) (*usecases.GetDa
 		}
 
 		daily := make([]usecases.DailyResult, 0)
-		end := t.clock.GetDay(selectedMonth).AddDate(0, 1, 0)
+		end := t.clock.AddMonths(selectedMonth, 1)
 		for day := t.clock.GetDay(selectedMonth); day.Before(end); day = t.clock.GetDay(&day).AddDate(0, 0, 1) {
 			key := day.Format("2006-01-02")
 			val, ok := dMap[key]
@@ -173,6 +177,7 @@ func (t *dashboard) getSummaryByMonth(selectedMonth *time.Time) (*usecases.GetDa
 			SelectedMonth:   *selectedMonth,
 			Plans:           plans,
 			Daily:           daily,
+			Categories:      convertCategories(currentDashboard.Categories),
 			State:           "closed",
 		}
 
@@ -287,11 +292,12 @@ func (t *dashboard) getSummaryByMonthWithoutPreviousDashboard(selectedMonth *tim
 		income += trn.income
 		expense += trn.expense
 		dMap = trn.dMap
+		result.Categories = convertCategories(trn.categories)
 		break
 	}
 
 	daily := make([]usecases.DailyResult, 0)
-	end := t.clock.GetDay(selectedMonth).AddDate(0, 1, 0)
+	end := t.clock.AddMonths(selectedMonth, 1)
 	total := 0
 	i := 0
 	now := t.clock.GetDay(nil)
@@ -339,69 +345,73 @@ func (t *dashboard) getDashboardByMonthWorker(selectedMonth *time.Time, chError
 	return ch
 }
 func (t *dashboard) getDashboardByPreviousMonthWorker(selectedMonth *time.Time, chError chan error) <-chan *models.Dashboard {
-	previousMonth := selectedMonth.AddDate(0, -1, 0)
+	previousMonth := t.clock.AddMonths(selectedMonth, -1)
 	return t.getDashboardByMonthWorker(&previousMonth, chError)
 }
 func (t *dashboard) getDashboardByNextMonthWorker(selectedMonth *time.Time, chError chan error) <-chan *models.Dashboard {
-	previousMonth := selectedMonth.AddDate(0, 1, 0)
+	previousMonth := t.clock.AddMonths(selectedMonth, 1)
 	return t.getDashboardByMonthWorker(&previousMonth, chError)
 }
 func (t *dashboard) getTransactionsSummaryWorker(selectedMonth *time.Time, chError chan error) <-chan struct {
-	income  int
-	expense int
-	dMap    map[string]usecases.DailyResult
+	income     int
+	expense    int
+	dMap       map[string]usecases.DailyResult
+	categories []models.CategorySummary
 } {
 	ch := make(chan struct {
-		income  int
-		expense int
-		dMap    map[string]usecases.DailyResult
+		income     int
+		expense    int
+		dMap       map[string]usecases.DailyResult
+		categories []models.CategorySummary
 	})
 	go func() {
-		transactions, err := t.transactionsRepos.GetByMonth(selectedMonth)
+		aggregate, err := t.getAggregate(selectedMonth)
 		if err != nil {
 			chError <- err
 			return
 		}
-		// 収入と支出を集計する
 		dMap := make(map[string]usecases.DailyResult)
-		income := 0
-		expense := 0
-		// 取引から集計
-		for _, transaction := range *transactions {
-			key := transaction.Date.Format("2006-01-02")
-			val, ok := dMap[key]
-			if !ok {
-				val = usecases.DailyResult{
-					Date:    t.clock.GetDay(&transaction.Date),
-					Balance: 0,
-					Expense: 0,
-					Income:  0,
-				}
-			}
-
-			if transaction.Category == 5 {
-				income += transaction.Amount
-				val.Income += transaction.Amount
-			} else {
-				expense += transaction.Amount
-				val.Expense += transaction.Amount
+		for _, daily := range aggregate.Daily {
+			dMap[daily.Date.Format("2006-01-02")] = usecases.DailyResult{
+				Date:    daily.Date,
+				Balance: 0,
+				Expense: daily.Expense,
+				Income:  daily.Income,
 			}
-
-			dMap[key] = val
 		}
 
 		ch <- struct {
-			income  int
-			expense int
-			dMap    map[string]usecases.DailyResult
+			income     int
+			expense    int
+			dMap       map[string]usecases.DailyResult
+			categories []models.CategorySummary
 		}{
-			income,
-			expense,
+			aggregate.Income,
+			aggregate.Expense,
 			dMap,
+			aggregate.Categories,
 		}
 	}()
 	return ch
 }
+
+// getAggregate は月の取引の集計を取得します
+// 集計がまだ作成されていない月は取引から集計します
+func (t *dashboard) getAggregate(selectedMonth *time.Time) (*models.Aggregate, error) {
+	aggregate, err := t.aggregatesRepos.GetByMonth(selectedMonth)
+	if err != nil {
+		return nil, err
+	}
+	if aggregate != nil {
+		return aggregate, nil
+	}
+	transactions, err := t.transactionsRepos.GetByMonth(selectedMonth)
+	if err != nil {
+		return nil, err
+	}
+	month := t.clock.GetMonthStartDay(selectedMonth)
+	return accountbook.BuildAggregate(month, *transactions, t.clock.GetDay), nil
+}
 func (t *dashboard) getPlansWorker(selectedMonth *time.Time, chError chan error) <-chan *map[string]usecases.PlanResult {
 	ch := make(chan *map[string]usecases.PlanResult)
 
@@ -425,3 +435,32 @@ func (t *dashboard) getPlansWorker(selectedMonth *time.Time, chError chan error)
 	}()
 	return ch
 }
+func convertCategories(categories []models.CategorySummary) []usecases.CategoryResult {
+	result := make([]usecases.CategoryResult, len(categories))
+	for i, category := range categories {
+		result[i] = usecases.CategoryResult{
+			Category:      category.Category,
+			Amount:        category.Amount,
+			Share:         accountbook.ExpenseShare(categories, category.Amount),
+			Count:         category.Count,
+			Uncategorized: category.Uncategorized,
+		}
+	}
+	return result
+}
+func (t *dashboard) GetAdjustments(id *string) (*usecases.GetAdjustmentsResult, error) {
+	adjustments, err := t.repos.GetAdjustments(id)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]usecases.AdjustmentResult, len(*adjustments))
+	for i, adjustment := range *adjustments {
+		result[i] = usecases.AdjustmentResult{
+			AdjustmentID: adjustment.AdjustmentID,
+			Amount:       adjustment.Amount,
+			Reason:       adjustment.Reason,
+			CreatedAt:    adjustment.CreatedAt,
+		}
+	}
+	return &usecases.GetAdjustmentsResult{Adjustments: result}, nil
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type digests struct {
+	repos application.UsersRepository
+}
+
+// NewDigests はインスタンスを生成します
+func NewDigests(repos application.UsersRepository) usecases.DigestsQuery {
+	return &digests{repos}
+}
+func (t *digests) GetSubscriberIDs() ([]string, error) {
+	users, err := t.repos.GetDigestSubscribers()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(*users))
+	for i, user := range *users {
+		ids[i] = user.UserID
+	}
+	return ids, nil
+}
@@ -0,0 +1,128 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+// unplannedAverageMonths は計画外の収支の平均を求める締め済みの月数です
+const unplannedAverageMonths = 3
+
+type forecast struct {
+	dashboardRepos application.DashboardRepository
+	plansRepos     application.PlansRepository
+	clock          core.Clock
+}
+
+// NewForecast はインスタンスを生成します
+func NewForecast(
+	dashboardRepos application.DashboardRepository,
+	plansRepos application.PlansRepository,
+	clock core.Clock,
+) usecases.ForecastQuery {
+	return &forecast{
+		dashboardRepos,
+		plansRepos,
+		clock,
+	}
+}
+
+func (t *forecast) GetForecast(args *usecases.GetForecastArgs) (*usecases.GetForecastResult, error) {
+	latest, err := t.dashboardRepos.GetLatestClosedDashboard()
+	if err != nil {
+		return nil, err
+	}
+	balance := 0
+	month := t.clock.GetMonthStartDay(nil)
+	if latest != nil {
+		if latest.Balance != nil {
+			balance = *latest.Balance
+		}
+		month = t.clock.AddMonths(&latest.Date, 1)
+	}
+	result := &usecases.GetForecastResult{
+		StartBalance: balance,
+		Months:       make([]usecases.ForecastMonthResult, args.Months),
+	}
+
+	unplannedIncome, unplannedExpense := 0, 0
+	if args.IncludeUnplanned && latest != nil {
+		unplannedIncome, unplannedExpense, err = t.getUnplannedAverage(latest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 計画は 1 度だけ読み込み、月毎に発生する計画へ展開します
+	plans, err := t.plansRepos.Get()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < args.Months; i++ {
+		next := t.clock.AddMonths(&month, 1)
+		income, expense := unplannedIncome, unplannedExpense
+		for _, plan := range accountbook.PlansInMonth(*plans, month, next) {
+			if plan.IsIncome {
+				income += plan.PlanAmount
+			} else {
+				expense += plan.PlanAmount
+			}
+		}
+		balance += income - expense
+		result.Months[i] = usecases.ForecastMonthResult{
+			Month:            month,
+			Income:           income,
+			Expense:          expense,
+			UnplannedIncome:  unplannedIncome,
+			UnplannedExpense: unplannedExpense,
+			Balance:          balance,
+		}
+		month = next
+	}
+	return result, nil
+}
+
+// getUnplannedAverage は直近の締め済みの月の計画外 (取引) の収支の平均を返します
+func (t *forecast) getUnplannedAverage(latest *models.Dashboard) (int, int, error) {
+	income, expense, count := 0, 0, 0
+	dashboard := latest
+	for count < unplannedAverageMonths && dashboard != nil && dashboard.State == "closed" {
+		i, e := unplanned(dashboard)
+		income += i
+		expense += e
+		count++
+		previousMonth := t.clock.AddMonths(&dashboard.Date, -1)
+		previous, err := t.dashboardRepos.GetByMonth(&previousMonth)
+		if err != nil {
+			return 0, 0, err
+		}
+		dashboard = previous
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return income / count, expense / count, nil
+}
+
+// unplanned は締め済みのダッシュボードの収支から計画分を除いた額を返します
+func unplanned(dashboard *models.Dashboard) (int, int) {
+	income, expense := 0, 0
+	if dashboard.Income != nil {
+		income = *dashboard.Income
+	}
+	if dashboard.Expense != nil {
+		expense = *dashboard.Expense
+	}
+	for _, actual := range dashboard.Actual {
+		if actual.IsIncome {
+			income -= actual.ActualAmount
+		} else {
+			expense -= actual.ActualAmount
+		}
+	}
+	return income, expense
+}
@@ -0,0 +1,25 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type notificationChannels struct {
+	repos notifications.ChannelSettingsRepository
+}
+
+// NewNotificationChannels はインスタンスを生成します
+func NewNotificationChannels(
+	repos notifications.ChannelSettingsRepository,
+) usecases.NotificationChannelsQuery {
+	return &notificationChannels{repos}
+}
+func (t *notificationChannels) GetNotificationChannels() *usecases.GetNotificationChannelsResult {
+	settings := t.repos.Get()
+	return &usecases.GetNotificationChannelsResult{
+		WebhookURL:       settings.GetWebhookURL(),
+		HasWebhookSecret: settings.GetWebhookSecret() != "",
+	}
+}
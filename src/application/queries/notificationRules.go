@@ -35,9 +35,26 @@ func (t *notificationRules) GetNotificationRule(id *string) (*usecases.GetNotifi
 }
 func convertNotificationRule(t *notifications.NotificationRule) *usecases.GetNotificationRuleResult {
 	notificationRule := *t
+	channels := make([]string, len(notificationRule.GetChannels()))
+	for i, channel := range notificationRule.GetChannels() {
+		channels[i] = channel.Get()
+	}
+	var lastValue *int
+	if observation := notificationRule.GetLastObservation(); observation != nil {
+		lastValue = &observation.Value
+	}
 	return &usecases.GetNotificationRuleResult{
 		NotificationRuleID: *notificationRule.GetID(),
 		Metrics:            notificationRule.GetMetrics().Get(),
 		Threshold:          notificationRule.GetThreshold().Get(),
+		ThresholdUnit:      notificationRule.GetThreshold().GetUnit(),
+		Operator:           notificationRule.GetOperator().Get(),
+		Period:             notificationRule.GetPeriod().Get(),
+		Category:           notificationRule.GetCategory(),
+		Channels:           channels,
+		Rearm:              notificationRule.GetRearm(),
+		CooldownHours:      notificationRule.GetCooldown().Get(),
+		LastFiredAt:        notificationRule.GetLastFiredAt(),
+		LastValue:          lastValue,
 	}
 }
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type inbox struct {
+	repos notifications.InboxRepository
+}
+
+// NewNotifications はインスタンスを生成します
+func NewNotifications(
+	repos notifications.InboxRepository,
+) usecases.NotificationsQuery {
+	return &inbox{repos}
+}
+func (t *inbox) GetNotifications(args *usecases.GetNotificationsArgs) (*usecases.GetNotificationsResult, core.Error) {
+	records, next, err := t.repos.Get(&notifications.InboxFilter{
+		Limit:      args.Limit,
+		Cursor:     args.Cursor,
+		UnreadOnly: args.UnreadOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]usecases.GetNotificationResult, len(*records))
+	for i, record := range *records {
+		items[i] = usecases.GetNotificationResult(record)
+	}
+	return &usecases.GetNotificationsResult{
+		Notifications: items,
+		NextCursor:    next,
+	}, nil
+}
+func (t *inbox) GetUnreadCount() *usecases.GetUnreadCountResult {
+	return &usecases.GetUnreadCountResult{Count: t.repos.CountUnread()}
+}
@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type payees struct {
+	repos application.PayeesRepository
+}
+
+// NewPayees はインスタンスを生成します
+func NewPayees(
+	repos application.PayeesRepository,
+) usecases.PayeesQuery {
+	return &payees{
+		repos,
+	}
+}
+func (t *payees) GetPayees() (*usecases.GetPayeesResult, error) {
+	records, err := t.repos.Get()
+	if err != nil {
+		return nil, err
+	}
+	payees := make([]usecases.GetPayeeResult, len(*records))
+	for i, record := range *records {
+		r := &record
+		payees[i] = *convertPayee(r)
+	}
+	return &usecases.GetPayeesResult{Payees: payees}, nil
+}
+func (t *payees) GetPayee(id *string) (*usecases.GetPayeeResult, error) {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return convertPayee(model), nil
+}
+func convertPayee(t *models.Payee) *usecases.GetPayeeResult {
+	return &usecases.GetPayeeResult{
+		PayeeID:   t.PayeeID,
+		PayeeName: t.PayeeName,
+	}
+}
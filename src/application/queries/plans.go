@@ -2,6 +2,7 @@ package queries
 
 import (
 	"github.com/wakuwaku3/account-book.api/src/application"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 
 	"github.com/wakuwaku3/account-book.api/src/application/usecases"
@@ -19,8 +20,12 @@ func NewPlans(
 		repos,
 	}
 }
-func (t *plans) GetPlans() (*usecases.GetPlansResult, error) {
-	records, err := t.repos.Get()
+func (t *plans) GetPlans(args *usecases.GetPlansArgs) (*usecases.GetPlansResult, error) {
+	get := t.repos.Get
+	if args.IncludeDeleted {
+		get = t.repos.GetIncludeDeleted
+	}
+	records, err := get()
 	if err != nil {
 		return nil, err
 	}
@@ -42,13 +47,35 @@ func (t *plans) GetPlan(id *string) (
 	return convertPlan(model), nil
 }
 func convertPlan(t *models.Plan) *usecases.GetPlanResult {
+	recurrence := accountbook.RecurrenceOf(t)
+	revisions := make([]usecases.PlanRevisionResult, len(t.Revisions))
+	for i, revision := range t.Revisions {
+		revisions[i] = usecases.PlanRevisionResult{
+			EffectiveFrom: revision.EffectiveFrom,
+			PlanName:      revision.PlanName,
+			PlanAmount:    revision.PlanAmount,
+			IsIncome:      revision.IsIncome,
+			CreatedAt:     revision.CreatedAt,
+		}
+	}
 	return &usecases.GetPlanResult{
 		PlanID:     t.PlanID,
 		PlanName:   t.PlanName,
 		IsIncome:   t.IsIncome,
 		PlanAmount: t.PlanAmount,
 		Interval:   t.Interval,
-		Start:      t.Start,
-		End:        t.End,
+		Recurrence: usecases.RecurrenceArgs{
+			Frequency:  recurrence.Frequency,
+			Interval:   recurrence.Interval,
+			ByMonth:    recurrence.ByMonth,
+			ByMonthDay: recurrence.ByMonthDay,
+			Count:      recurrence.Count,
+			Until:      recurrence.Until,
+		},
+		Start:     t.Start,
+		End:       t.End,
+		Revisions: revisions,
+		IsDeleted: t.IsDeleted,
+		DeletedAt: t.DeletedAt,
 	}
 }
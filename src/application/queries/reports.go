@@ -0,0 +1,246 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type reports struct {
+	dashboardRepos    application.DashboardRepository
+	plansRepos        application.PlansRepository
+	transactionsRepos application.TransactionsRepository
+	clock             core.Clock
+}
+
+// NewReports はインスタンスを生成します
+func NewReports(
+	dashboardRepos application.DashboardRepository,
+	plansRepos application.PlansRepository,
+	transactionsRepos application.TransactionsRepository,
+	clock core.Clock,
+) usecases.ReportsQuery {
+	return &reports{
+		dashboardRepos,
+		plansRepos,
+		transactionsRepos,
+		clock,
+	}
+}
+
+func (t *reports) GetMonthlyReport(args *usecases.GetMonthlyReportArgs) (*usecases.GetMonthlyReportResult, error) {
+	from := t.clock.GetMonthStartDay(&args.From)
+	to := t.clock.GetMonthStartDay(&args.To)
+	end := t.clock.AddMonths(&to, 1)
+
+	// 締め前の月は取引から集計するため、期間の取引をまとめて取得します
+	monthly, err := t.getMonthlyTransactions(from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	cumulative, err := t.getBalanceBefore(from)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]usecases.MonthlyReportResult, 0)
+	for month := from; month.Before(end); month = t.clock.AddMonths(&month, 1) {
+		m := month
+		dashboard, err := t.dashboardRepos.GetByMonth(&m)
+		if err != nil {
+			return nil, err
+		}
+		x := usecases.MonthlyReportResult{Month: m, State: "open"}
+		if dashboard != nil && dashboard.State == "closed" {
+			// 締め済みの月は保存済みの集計を使います
+			x.State = "closed"
+			if dashboard.Income != nil {
+				x.Income = *dashboard.Income
+			}
+			if dashboard.Expense != nil {
+				x.Expense = *dashboard.Expense
+			}
+			x.Balance = x.Income - x.Expense
+			cumulative += x.Balance
+			if dashboard.Balance != nil {
+				cumulative = *dashboard.Balance
+			}
+		} else {
+			x.Income, x.Expense, err = t.summarizeOpenMonth(m, dashboard, monthly[m.Format("2006-01-02")])
+			if err != nil {
+				return nil, err
+			}
+			x.Balance = x.Income - x.Expense
+			cumulative += x.Balance
+		}
+		x.CumulativeBalance = cumulative
+		months = append(months, x)
+	}
+	return &usecases.GetMonthlyReportResult{Months: months}, nil
+}
+
+// getBalanceBefore は指定月の前月末の残高を返します
+// 指定月より前に最後に締めた月の残高に、その翌月から指定月の前月までの締め前の月の収支の見込みを加えます
+// 締め済みの月が無い場合は最も古い締め前の月から見込みを加えます
+func (t *reports) getBalanceBefore(from time.Time) (int, error) {
+	latest, err := t.dashboardRepos.GetLatestClosedDashboard()
+	if err != nil {
+		return 0, err
+	}
+	balance := 0
+	var start time.Time
+	if latest != nil && !latest.Date.Before(from) {
+		// 締め済みの月は古い月から連続するため、指定月以降が締め済みの場合は前月の残高をそのまま使います
+		previousMonth := t.clock.AddMonths(&from, -1)
+		previous, err := t.dashboardRepos.GetByMonth(&previousMonth)
+		if err != nil {
+			return 0, err
+		}
+		if previous != nil && previous.State == "closed" && previous.Balance != nil {
+			balance = *previous.Balance
+		}
+		return balance, nil
+	}
+	if latest != nil {
+		if latest.Balance != nil {
+			balance = *latest.Balance
+		}
+		start = t.clock.AddMonths(&latest.Date, 1)
+	} else {
+		oldest, err := t.dashboardRepos.GetOldestOpenDashboard()
+		if err != nil {
+			return 0, err
+		}
+		if oldest == nil {
+			return balance, nil
+		}
+		start = t.clock.GetMonthStartDay(&oldest.Date)
+	}
+	if !start.Before(from) {
+		return balance, nil
+	}
+
+	monthly, err := t.getMonthlyTransactions(start, from)
+	if err != nil {
+		return 0, err
+	}
+	for month := start; month.Before(from); month = t.clock.AddMonths(&month, 1) {
+		m := month
+		dashboard, err := t.dashboardRepos.GetByMonth(&m)
+		if err != nil {
+			return 0, err
+		}
+		income, expense, err := t.summarizeOpenMonth(m, dashboard, monthly[m.Format("2006-01-02")])
+		if err != nil {
+			return 0, err
+		}
+		balance += income - expense
+	}
+	return balance, nil
+}
+
+// getMonthlyTransactions は期間の取引をまとめて取得し、月毎に分けて返します
+func (t *reports) getMonthlyTransactions(start time.Time, end time.Time) (map[string][]models.Transaction, error) {
+	transactions, err := t.transactionsRepos.GetByDateRange(&start, &end)
+	if err != nil {
+		return nil, err
+	}
+	monthly := make(map[string][]models.Transaction)
+	for _, transaction := range *transactions {
+		key := t.clock.GetMonthStartDay(&transaction.Date).Format("2006-01-02")
+		monthly[key] = append(monthly[key], transaction)
+	}
+	return monthly, nil
+}
+
+// summarizeOpenMonth は締め前の月の収入と支出を計画・実績・取引から求めます
+func (t *reports) summarizeOpenMonth(month time.Time, dashboard *models.Dashboard, transactions []models.Transaction) (int, int, error) {
+	plans, err := t.plansRepos.GetByMonth(&month)
+	if err != nil {
+		return 0, 0, err
+	}
+	var actuals []models.Actual
+	if dashboard != nil {
+		actuals = dashboard.Actual
+	}
+	income, expense := accountbook.SummarizeMonth(*plans, actuals, transactions)
+	return income, expense, nil
+}
+
+// GetNetWorth は締め済みの月末残高と、締め前の月の残高の見込みを返します
+// 日単位の場合、締め済みの月は保存済みの日毎の取引から日々の残高を求めます
+// 実績は日付を持たず、残高の調整は締めた後に月末の残高へ加えられるため、どちらも月末の点にまとめて反映します
+func (t *reports) GetNetWorth(args *usecases.GetNetWorthArgs) (*usecases.GetNetWorthResult, error) {
+	dashboards, err := t.dashboardRepos.GetClosedDashboards()
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]usecases.NetWorthPoint, 0)
+	for _, dashboard := range *dashboards {
+		if args.Granularity == usecases.GranularityDaily {
+			daily, err := t.dashboardRepos.GetDaily(&dashboard.DashboardID)
+			if err != nil {
+				return nil, err
+			}
+			balance := 0
+			if dashboard.PreviousBalance != nil {
+				balance = *dashboard.PreviousBalance
+			}
+			monthEnd := t.getMonthEndDay(&dashboard.Date)
+			for _, d := range *daily {
+				// 月末の取引は実績・調整と合わせて月末の点に含めます
+				if !d.Date.Before(monthEnd) {
+					continue
+				}
+				balance += d.Income - d.Expense
+				points = append(points, usecases.NetWorthPoint{Date: d.Date, Balance: balance})
+			}
+		}
+		balance := 0
+		if dashboard.Balance != nil {
+			balance = *dashboard.Balance
+		}
+		points = append(points, usecases.NetWorthPoint{
+			Date:    t.getMonthEndDay(&dashboard.Date),
+			Balance: balance,
+		})
+	}
+
+	// 最後に締めた月の翌月から当月までは計画・実績・取引から見込みを求めます
+	current := t.clock.GetMonthStartDay(nil)
+	from := current
+	if len(*dashboards) > 0 {
+		latest := (*dashboards)[len(*dashboards)-1]
+		from = t.clock.AddMonths(&latest.Date, 1)
+	}
+	if from.After(current) {
+		return &usecases.GetNetWorthResult{Points: points}, nil
+	}
+	monthly, err := t.GetMonthlyReport(&usecases.GetMonthlyReportArgs{
+		From: from,
+		To:   current,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, month := range monthly.Months {
+		m := month.Month
+		points = append(points, usecases.NetWorthPoint{
+			Date:      t.getMonthEndDay(&m),
+			Balance:   month.CumulativeBalance,
+			Estimated: true,
+		})
+	}
+	return &usecases.GetNetWorthResult{Points: points}, nil
+}
+func (t *reports) getMonthEndDay(month *time.Time) time.Time {
+	last := t.clock.AddMonths(month, 1).Add(-time.Nanosecond)
+	return t.clock.GetDay(&last)
+}
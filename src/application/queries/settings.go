@@ -0,0 +1,49 @@
+package queries
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+
+	"github.com/wakuwaku3/account-book.api/src/application/usecases"
+)
+
+type settings struct {
+	repos application.UsersRepository
+	clock core.Clock
+}
+
+// NewSettings はインスタンスを生成します
+func NewSettings(
+	repos application.UsersRepository,
+	clock core.Clock,
+) usecases.SettingsQuery {
+	return &settings{
+		repos,
+		clock,
+	}
+}
+func (t *settings) GetSettings() (*usecases.GetSettingsResult, error) {
+	model, err := t.repos.GetByAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &usecases.GetSettingsResult{
+		UserName:        model.UserName,
+		Email:           model.Email,
+		Culture:         model.Culture,
+		MonthStartDay:   t.clock.MonthStartDay(),
+		TimeZone:        t.clock.DefaultLocation().String(),
+		CurrentMonthDay: t.clock.GetMonthStartDay(nil),
+	}, nil
+}
+func (t *settings) GetDigestSettings() (*usecases.GetDigestSettingsResult, error) {
+	model, err := t.repos.GetByAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &usecases.GetDigestSettingsResult{
+		WeeklyDigest:   model.WeeklyDigest,
+		DigestWeekday:  model.DigestWeekday,
+		MonthEndDigest: model.MonthEndDigest,
+	}, nil
+}
@@ -2,6 +2,8 @@ package queries
 
 import (
 	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 
 	"github.com/wakuwaku3/account-book.api/src/application/usecases"
@@ -9,14 +11,17 @@ import (
 
 type transactions struct {
 	repos application.TransactionsRepository
+	clock core.Clock
 }
 
 // NewTransactions はインスタンスを生成します
 func NewTransactions(
 	repos application.TransactionsRepository,
+	clock core.Clock,
 ) usecases.TransactionsQuery {
 	return &transactions{
 		repos,
+		clock,
 	}
 }
 func (t *transactions) GetTransactions(
@@ -45,11 +50,44 @@ func (t *transactions) GetTransaction(id *string) (
 }
 func convertTransaction(model *models.Transaction) *usecases.GetTransactionResult {
 	return &usecases.GetTransactionResult{
-		Amount:        model.Amount,
-		Category:      model.Category,
-		Date:          model.Date,
-		Notes:         model.Notes,
-		TransactionID: model.TransactionID,
-		Editable:      model.DailyID == nil,
+		Amount:          model.Amount,
+		Category:        model.Category,
+		Uncategorized:   model.Uncategorized,
+		Date:            model.Date,
+		Notes:           model.Notes,
+		ImportReference: model.ImportReference,
+		ImportSource:    model.ImportSource,
+		PayeeID:         model.PayeeID,
+		Tags:            model.Tags,
+		TransactionID:   model.TransactionID,
+		Editable:        model.DailyID == nil,
 	}
 }
+func (t *transactions) GetDuplicates(args *usecases.GetDuplicatesArgs) (*usecases.GetDuplicatesResult, error) {
+	start := t.clock.GetMonthStartDay(&args.SelectedMonth)
+	end := t.clock.AddMonths(&start, 1)
+	// 月をまたいだ重複も検出できるよう前後に広げて取得する
+	from := start.AddDate(0, 0, -args.Days)
+	to := end.AddDate(0, 0, args.Days)
+	records, err := t.repos.GetByDateRange(&from, &to)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]usecases.DuplicateGroupResult, 0)
+	for _, group := range accountbook.GroupDuplicates(*records, args.Days) {
+		inMonth := false
+		transactions := make([]usecases.GetTransactionResult, len(group))
+		for i, record := range group {
+			r := &record
+			if !r.Date.Before(start) && r.Date.Before(end) {
+				inMonth = true
+			}
+			transactions[i] = *convertTransaction(r)
+		}
+		if inMonth {
+			groups = append(groups, usecases.DuplicateGroupResult{Transactions: transactions})
+		}
+	}
+	return &usecases.GetDuplicatesResult{Groups: groups}, nil
+}
@@ -169,6 +169,7 @@ func (t *accounts) CreateUser(args *CreateUserArgs) (*CreateUserResult, error) {
 			UserID:       account.UserID,
 			UserName:     user.UserName,
 			Culture:      user.Culture,
+			TimeZone:     user.TimeZone,
 			UseStartDate: user.UseStartDate,
 		},
 		JwtRefreshClaims: application.JwtRefreshClaims{
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	aggregates struct {
+		repos application.AggregatesRepository
+		clock core.Clock
+	}
+	// Aggregates is AggregatesService
+	Aggregates interface {
+		Repair(args *RepairAggregatesArgs) (*RepairAggregatesResult, error)
+	}
+	// RepairAggregatesArgs は引数です
+	RepairAggregatesArgs struct {
+		From time.Time
+		To   time.Time
+	}
+	// RepairAggregatesResult は結果です
+	RepairAggregatesResult struct {
+		Months []time.Time
+	}
+)
+
+// NewAggregates is create instance
+func NewAggregates(repos application.AggregatesRepository, clock core.Clock) Aggregates {
+	return &aggregates{repos, clock}
+}
+
+// Repair は期間の各月の集計を取引から作り直します
+func (t *aggregates) Repair(args *RepairAggregatesArgs) (*RepairAggregatesResult, error) {
+	from := t.clock.GetMonthStartDay(&args.From)
+	to := t.clock.GetMonthStartDay(&args.To)
+	result := &RepairAggregatesResult{Months: make([]time.Time, 0)}
+	for month := from; !month.After(to); month = t.clock.AddMonths(&month, 1) {
+		m := month
+		if _, err := t.repos.Rebuild(&m); err != nil {
+			return nil, err
+		}
+		result.Months = append(result.Months, m)
+	}
+	return result, nil
+}
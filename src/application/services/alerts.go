@@ -0,0 +1,189 @@
+package services
+
+import (
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	alerts struct {
+		repos             notifications.NotificationRulesRepository
+		dashboardRepos    application.DashboardRepository
+		transactionsRepos application.TransactionsRepository
+		notifier          notifications.Notifier
+		clock             core.Clock
+	}
+	// Alerts is AlertsService
+	Alerts interface {
+		Evaluate() (*EvaluateAlertsResult, error)
+	}
+	// EvaluateAlertsResult は結果です
+	EvaluateAlertsResult struct {
+		Notifications []notifications.Notification
+	}
+)
+
+// NewAlerts is create instance
+func NewAlerts(
+	repos notifications.NotificationRulesRepository,
+	dashboardRepos application.DashboardRepository,
+	transactionsRepos application.TransactionsRepository,
+	notifier notifications.Notifier,
+	clock core.Clock,
+) Alerts {
+	return &alerts{
+		repos,
+		dashboardRepos,
+		transactionsRepos,
+		notifier,
+		clock,
+	}
+}
+
+// Evaluate はユーザーの通知ルールを当月の収支で評価し、新たに閾値を超えたルールを通知します
+// 配信に失敗した配信先は通知ルールに記録して他のルールの評価を続け、最後にエラーを返します
+// 記録した配信先には次の評価で配信し直すため、エラーによりイベントが再配信されても配信できた配信先には再び通知しません
+// 再配信は notifications.MaxDeliveryAttempts 回までとし、上限に達した通知は破棄します
+func (t *alerts) Evaluate() (*EvaluateAlertsResult, error) {
+	rules := t.repos.Get()
+	result := &EvaluateAlertsResult{Notifications: make([]notifications.Notification, 0)}
+	if len(*rules) == 0 {
+		return result, nil
+	}
+	snapshot, err := t.getSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	var failed error
+	for _, rule := range *rules {
+		// 同じユーザーのイベントを並行して処理しても重複して通知しないよう、
+		// 評価と再配信を待っている通知の取り出しは排他的に行います
+		var deliveries []notifications.Notification
+		err := t.repos.Modify(rule.GetID(), func(current notifications.NotificationRule) bool {
+			deliveries = current.GetPendingNotifications()
+			n, changed := current.Evaluate(snapshot)
+			if n != nil {
+				deliveries = append(deliveries, *n)
+			}
+			if len(current.GetPendingNotifications()) > 0 {
+				current.SetPendingNotifications(nil)
+				changed = true
+			}
+			return changed
+		})
+		if err != nil {
+			// 評価中に削除されたルールは評価しません
+			continue
+		}
+		pending := make([]notifications.Notification, 0)
+		for _, delivery := range deliveries {
+			notification := delivery
+			failedChannels, err := t.notifier.Notify(&notification)
+			if err != nil {
+				log.Error(err)
+				failed = err
+			}
+			if len(failedChannels) > 0 {
+				notification.Channels = failedChannels
+				notification.Attempts++
+				if notification.Attempts >= notifications.MaxDeliveryAttempts {
+					log.Warnf("配信先(%v)への配信に%d回失敗したため通知を破棄します", failedChannels, notification.Attempts)
+					continue
+				}
+				pending = append(pending, notification)
+				continue
+			}
+			result.Notifications = append(result.Notifications, notification)
+		}
+		if len(pending) > 0 {
+			t.keepPending(rule.GetID(), pending)
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	return result, nil
+}
+
+// keepPending は配信に失敗した配信先への通知を、再配信を待っている通知として記録します
+func (t *alerts) keepPending(id notifications.NotificationRuleID, pending []notifications.Notification) {
+	err := t.repos.Modify(id, func(current notifications.NotificationRule) bool {
+		current.SetPendingNotifications(append(current.GetPendingNotifications(), pending...))
+		return true
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// getSnapshot は当月の実績と取引、最後に締めた月の残高から当月の収支を求めます
+// 計画は実際の入出金ではないため、実績が未入力の計画は含めません
+// 週単位の評価のため、当週が前月から始まる場合は前月の取引も含めます
+func (t *alerts) getSnapshot() (*notifications.Snapshot, error) {
+	month := t.clock.GetMonthStartDay(nil)
+	// Firestore はマイクロ秒までしか保存しないため、保存する通知日時と精度を揃えます
+	now := t.clock.Now().Truncate(time.Microsecond)
+	snapshot := &notifications.Snapshot{Month: month, NextMonth: t.clock.AddMonths(&month, 1), Now: now}
+
+	latest, err := t.dashboardRepos.GetLatestClosedDashboard()
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Date.Before(month) && latest.Balance != nil {
+		snapshot.PreviousBalance = *latest.Balance
+	}
+
+	transactions, err := t.transactionsRepos.GetByMonth(&month)
+	if err != nil {
+		return nil, err
+	}
+	week, _ := notifications.NewPeriod(notifications.WeekPeriod)
+	weekStart, _ := week.Range(snapshot)
+	if weekStart.Before(month) {
+		previousMonth := t.clock.AddMonths(&month, -1)
+		previous, err := t.transactionsRepos.GetByMonth(&previousMonth)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Transactions = toSnapshotTransactions(*previous)
+	}
+	snapshot.Transactions = append(snapshot.Transactions, toSnapshotTransactions(*transactions)...)
+
+	current, err := t.dashboardRepos.GetByMonth(&month)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.State == "closed" && current.Income != nil && current.Expense != nil {
+		snapshot.Income = *current.Income
+		snapshot.Expense = *current.Expense
+		if current.PreviousBalance != nil {
+			snapshot.PreviousBalance = *current.PreviousBalance
+		}
+		return snapshot, nil
+	}
+	var actuals []models.Actual
+	if current != nil {
+		actuals = current.Actual
+	}
+	snapshot.Income, snapshot.Expense = accountbook.SummarizeMonth(nil, actuals, *transactions)
+	return snapshot, nil
+}
+func toSnapshotTransactions(transactions []models.Transaction) []notifications.SnapshotTransaction {
+	x := make([]notifications.SnapshotTransaction, len(transactions))
+	for i, transaction := range transactions {
+		x[i] = notifications.SnapshotTransaction{
+			Date:          transaction.Date,
+			Category:      transaction.Category,
+			Amount:        transaction.Amount,
+			IsIncome:      transaction.Category == accountbook.IncomeCategory && !transaction.Uncategorized,
+			Uncategorized: transaction.Uncategorized,
+		}
+	}
+	return x
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	attachments struct {
+		repos       application.TransactionsRepository
+		blobStore   application.BlobStore
+		thumbnailer application.Thumbnailer
+		clock       core.Clock
+	}
+	// Attachments is AttachmentsService
+	Attachments interface {
+		Upload(args *UploadAttachmentArgs) (*UploadAttachmentResult, error)
+		Delete(transactionID *string, id *string) error
+	}
+	// UploadAttachmentArgs は引数です
+	UploadAttachmentArgs struct {
+		TransactionID string
+		FileName      string
+		ContentType   string
+		Data          []byte
+	}
+	// UploadAttachmentResult は結果です
+	UploadAttachmentResult struct {
+		AttachmentID string
+	}
+)
+
+// NewAttachments is create instance
+func NewAttachments(
+	repos application.TransactionsRepository,
+	blobStore application.BlobStore,
+	thumbnailer application.Thumbnailer,
+	clock core.Clock,
+) Attachments {
+	return &attachments{repos, blobStore, thumbnailer, clock}
+}
+func (t *attachments) Upload(args *UploadAttachmentArgs) (*UploadAttachmentResult, error) {
+	if _, err := t.repos.Get(&args.TransactionID); err != nil {
+		return nil, err
+	}
+	thumbnail, err := t.thumbnailer.Create(args.ContentType, args.Data)
+	if err != nil {
+		return nil, core.NewError(application.NotSupportedContentType)
+	}
+
+	id, err := t.repos.CreateAttachment(&args.TransactionID, &models.Attachment{
+		FileName:     args.FileName,
+		ContentType:  args.ContentType,
+		Size:         len(args.Data),
+		HasThumbnail: thumbnail != nil,
+		CreatedAt:    t.clock.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := t.put(&args.TransactionID, id, args.Data, thumbnail); err != nil {
+		t.repos.DeleteAttachment(&args.TransactionID, id)
+		return nil, err
+	}
+	return &UploadAttachmentResult{AttachmentID: *id}, nil
+}
+func (t *attachments) put(transactionID *string, id *string, data []byte, thumbnail *[]byte) error {
+	if err := t.blobStore.Put(application.AttachmentKey(*transactionID, *id), data); err != nil {
+		return err
+	}
+	if thumbnail == nil {
+		return nil
+	}
+	return t.blobStore.Put(application.ThumbnailKey(*transactionID, *id), *thumbnail)
+}
+func (t *attachments) Delete(transactionID *string, id *string) error {
+	model, err := t.repos.GetAttachment(transactionID, id)
+	if err != nil {
+		return err
+	}
+	return deleteAttachment(t.repos, t.blobStore, transactionID, model)
+}
+
+// purgeAttachments は取引に紐づく添付ファイルをすべて削除します
+func purgeAttachments(
+	repos application.TransactionsRepository,
+	blobStore application.BlobStore,
+	transactionID *string,
+) error {
+	attachments, err := repos.GetAttachments(transactionID)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range *attachments {
+		a := attachment
+		if err := deleteAttachment(repos, blobStore, transactionID, &a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func deleteAttachment(
+	repos application.TransactionsRepository,
+	blobStore application.BlobStore,
+	transactionID *string,
+	model *models.Attachment,
+) error {
+	if err := blobStore.Delete(application.AttachmentKey(*transactionID, model.AttachmentID)); err != nil {
+		return err
+	}
+	if model.HasThumbnail {
+		if err := blobStore.Delete(application.ThumbnailKey(*transactionID, model.AttachmentID)); err != nil {
+			return err
+		}
+	}
+	return repos.DeleteAttachment(transactionID, &model.AttachmentID)
+}
+
+// moveAttachments は添付ファイルを別の取引に付け替えます
+func moveAttachments(
+	repos application.TransactionsRepository,
+	blobStore application.BlobStore,
+	from *string,
+	to *string,
+) error {
+	attachments, err := repos.GetAttachments(from)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range *attachments {
+		a := attachment
+		data, err := blobStore.Get(application.AttachmentKey(*from, a.AttachmentID))
+		if err != nil {
+			return err
+		}
+		id, err := repos.CreateAttachment(to, &a)
+		if err != nil {
+			return err
+		}
+		if err := blobStore.Put(application.AttachmentKey(*to, *id), *data); err != nil {
+			return err
+		}
+		if a.HasThumbnail {
+			thumbnail, err := blobStore.Get(application.ThumbnailKey(*from, a.AttachmentID))
+			if err != nil {
+				return err
+			}
+			if err := blobStore.Put(application.ThumbnailKey(*to, *id), *thumbnail); err != nil {
+				return err
+			}
+		}
+		if err := deleteAttachment(repos, blobStore, from, &a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
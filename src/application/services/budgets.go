@@ -0,0 +1,92 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	budgets struct {
+		repos application.BudgetsRepository
+		clock core.Clock
+	}
+	// Budgets is BudgetsService
+	Budgets interface {
+		Create(args *BudgetArgs) (*CreateBudgetResult, error)
+		Update(id *string, args *BudgetArgs) error
+		Delete(id *string) error
+	}
+	// BudgetArgs は引数です
+	BudgetArgs struct {
+		Category int
+		Month    time.Time
+		Amount   int
+		Rollover bool
+	}
+	// CreateBudgetResult は結果です
+	CreateBudgetResult struct {
+		BudgetID string
+	}
+)
+
+// NewBudgets is create instance
+func NewBudgets(repos application.BudgetsRepository, clock core.Clock) Budgets {
+	return &budgets{repos, clock}
+}
+func (t *budgets) Create(args *BudgetArgs) (*CreateBudgetResult, error) {
+	month := t.clock.GetMonthStartDay(&args.Month)
+	if err := t.existsBudget(nil, args.Category, month); err != nil {
+		return nil, err
+	}
+	id, err := t.repos.Create(&models.Budget{
+		Category: args.Category,
+		Month:    month,
+		Amount:   args.Amount,
+		Rollover: args.Rollover,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreateBudgetResult{BudgetID: *id}, nil
+}
+
+// existsBudget は同じカテゴリと月の予算が他に存在する場合エラーを返します
+func (t *budgets) existsBudget(id *string, category int, month time.Time) error {
+	budgets, err := t.repos.Get()
+	if err != nil {
+		return err
+	}
+	for _, budget := range *budgets {
+		if id != nil && budget.BudgetID == *id {
+			continue
+		}
+		if budget.Category == category && budget.Month.Equal(month) {
+			return core.NewError(application.DuplicateBudget)
+		}
+	}
+	return nil
+}
+func (t *budgets) Update(id *string, args *BudgetArgs) error {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return err
+	}
+	month := t.clock.GetMonthStartDay(&args.Month)
+	if err := t.existsBudget(id, args.Category, month); err != nil {
+		return err
+	}
+	model.Category = args.Category
+	model.Month = month
+	model.Amount = args.Amount
+	model.Rollover = args.Rollover
+	return t.repos.Update(id, model)
+}
+func (t *budgets) Delete(id *string) error {
+	if _, err := t.repos.GetByID(id); err != nil {
+		return err
+	}
+	return t.repos.Delete(id)
+}
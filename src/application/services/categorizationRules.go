@@ -0,0 +1,200 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	categorizationRules struct {
+		repos              application.CategorizationRulesRepository
+		payeesRepos        application.PayeesRepository
+		transactionsRepos  application.TransactionsRepository
+		clock              core.Clock
+		assetsChangedEvent accountbook.AssetsChangedEvent
+	}
+	// CategorizationRules is CategorizationRulesService
+	CategorizationRules interface {
+		Create(args *CategorizationRuleArgs) (*CreateCategorizationRuleResult, error)
+		Update(id *string, args *CategorizationRuleArgs) error
+		Delete(id *string) error
+		Apply(args *ApplyCategorizationRulesArgs) (*[]CategorizationChange, error)
+	}
+	// CategorizationRuleArgs は引数です
+	CategorizationRuleArgs struct {
+		RuleName     string
+		Priority     int
+		NotesPattern *string
+		PayeePattern *string
+		MinAmount    *int
+		MaxAmount    *int
+		ImportSource *string
+		Category     *int
+		Tags         []string
+		PayeeID      *string
+	}
+	// CreateCategorizationRuleResult は結果です
+	CreateCategorizationRuleResult struct {
+		RuleID string
+	}
+	// ApplyCategorizationRulesArgs は引数です
+	ApplyCategorizationRulesArgs struct {
+		SelectedMonth time.Time
+		Preview       bool
+	}
+	// CategorizationChange はルールの適用による取引の変更内容です
+	CategorizationChange struct {
+		TransactionID  string
+		BeforeCategory int
+		Category       int
+		PayeeID        *string
+		Tags           []string
+	}
+)
+
+// NewCategorizationRules is create instance
+func NewCategorizationRules(
+	repos application.CategorizationRulesRepository,
+	payeesRepos application.PayeesRepository,
+	transactionsRepos application.TransactionsRepository,
+	clock core.Clock,
+	assetsChangedEvent accountbook.AssetsChangedEvent,
+) CategorizationRules {
+	return &categorizationRules{
+		repos,
+		payeesRepos,
+		transactionsRepos,
+		clock,
+		assetsChangedEvent,
+	}
+}
+func (t *categorizationRules) Create(args *CategorizationRuleArgs) (*CreateCategorizationRuleResult, error) {
+	model := args.convert()
+	model.CreatedAt = t.clock.Now()
+	id, err := t.repos.Create(model)
+	if err != nil {
+		return nil, err
+	}
+	return &CreateCategorizationRuleResult{RuleID: *id}, nil
+}
+func (t *CategorizationRuleArgs) convert() *models.CategorizationRule {
+	return &models.CategorizationRule{
+		RuleName:     t.RuleName,
+		Priority:     t.Priority,
+		NotesPattern: t.NotesPattern,
+		PayeePattern: t.PayeePattern,
+		MinAmount:    t.MinAmount,
+		MaxAmount:    t.MaxAmount,
+		ImportSource: t.ImportSource,
+		Category:     t.Category,
+		Tags:         t.Tags,
+		PayeeID:      t.PayeeID,
+	}
+}
+func (t *categorizationRules) Update(id *string, args *CategorizationRuleArgs) error {
+	current, err := t.repos.GetByID(id)
+	if err != nil {
+		return err
+	}
+	model := args.convert()
+	model.CreatedAt = current.CreatedAt
+	return t.repos.Update(id, model)
+}
+func (t *categorizationRules) Delete(id *string) error {
+	if _, err := t.repos.GetByID(id); err != nil {
+		return err
+	}
+	return t.repos.Delete(id)
+}
+func (t *categorizationRules) Apply(args *ApplyCategorizationRulesArgs) (*[]CategorizationChange, error) {
+	categorizer, err := newCategorizer(t.repos, t.payeesRepos)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := t.transactionsRepos.GetByMonth(&args.SelectedMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]CategorizationChange, 0)
+	items := make([]application.TransactionsBatchItem, 0)
+	for _, transaction := range *transactions {
+		model := transaction
+		if model.DailyID != nil || !model.Uncategorized {
+			continue
+		}
+		model.Tags = append([]string{}, transaction.Tags...)
+		if !categorizer.categorize(&model) {
+			continue
+		}
+		changes = append(changes, CategorizationChange{
+			TransactionID:  model.TransactionID,
+			BeforeCategory: transaction.Category,
+			Category:       model.Category,
+			PayeeID:        model.PayeeID,
+			Tags:           model.Tags,
+		})
+		id := model.TransactionID
+		items = append(items, application.TransactionsBatchItem{
+			Operation:     application.BatchUpdate,
+			TransactionID: &id,
+			Model:         &model,
+		})
+	}
+	if args.Preview || len(items) == 0 {
+		return &changes, nil
+	}
+
+	// 1 回に反映できる件数を超える場合は分けて反映します
+	// 途中で失敗しても反映済みの取引は分類済みとなるため、再度適用すると残りの取引のみを分類します
+	for start := 0; start < len(items); start += application.MaxTransactionsBatchSize {
+		end := start + application.MaxTransactionsBatchSize
+		if end > len(items) {
+			end = len(items)
+		}
+		chunk := items[start:end]
+		if _, err := t.transactionsRepos.Batch(&chunk); err != nil {
+			if start > 0 {
+				t.assetsChangedEvent.Trigger()
+			}
+			return nil, err
+		}
+	}
+	t.assetsChangedEvent.Trigger()
+	return &changes, nil
+}
+
+type categorizer struct {
+	rules  []models.CategorizationRule
+	payees map[string]string
+}
+
+// newCategorizer はルールと支払先を読み込んで自動分類の準備をします
+func newCategorizer(
+	rulesRepos application.CategorizationRulesRepository,
+	payeesRepos application.PayeesRepository,
+) (*categorizer, error) {
+	rules, err := rulesRepos.Get()
+	if err != nil {
+		return nil, err
+	}
+	payees, err := payeesRepos.Get()
+	if err != nil {
+		return nil, err
+	}
+	payeeMap := make(map[string]string)
+	for _, payee := range *payees {
+		payeeMap[payee.PayeeID] = payee.PayeeName
+	}
+	return &categorizer{*rules, payeeMap}, nil
+}
+func (t *categorizer) categorize(model *models.Transaction) bool {
+	if len(t.rules) == 0 {
+		return false
+	}
+	return accountbook.Categorize(t.rules, model, t.payees)
+}
@@ -22,13 +22,48 @@ type (
 	// Dashboard is DashboardService
 	Dashboard interface {
 		Approve(id *string) error
+		ApproveThrough(args *ApproveThroughArgs) (*ApproveThroughResult, error)
 		CancelApprove(id *string) error
-		AdjustBalance(args *AdjustBalanceArgs) error
+		CancelApproveCascade(args *CancelApproveCascadeArgs) (*CancelApproveCascadeResult, error)
+		AdjustBalance(args *AdjustBalanceArgs) (*AdjustBalanceResult, error)
+	}
+	// ApproveThroughArgs は引数です
+	ApproveThroughArgs struct {
+		Month time.Time
+		// Atomic が true の場合、全ての月を締められる場合のみ 1 回の書き込みでまとめて締めます
+		// 書き込みが上限を超える場合は TooManyOperations を返し、いずれの月も締めません
+		// false の場合は月毎に書き込み、実績が未入力の月の前月まで締めます
+		Atomic bool
+	}
+	// ApproveThroughResult は結果です
+	ApproveThroughResult struct {
+		ApprovedMonths []time.Time
+		StoppedMonth   *time.Time
+		MissingPlanIDs []string
+	}
+	// CancelApproveCascadeArgs は引数です
+	CancelApproveCascadeArgs struct {
+		DashboardID string
+		// Reclose が true の場合、取り消した月を前月からの残高を再計算して締め直します
+		Reclose bool
+	}
+	// CancelApproveCascadeResult は結果です
+	CancelApproveCascadeResult struct {
+		ReopenedMonths []time.Time
+		ReclosedMonths []time.Time
+		StoppedMonth   *time.Time
+		MissingPlanIDs []string
 	}
 	// AdjustBalanceArgs は引数です
 	AdjustBalanceArgs struct {
 		DashboardID string
-		Balance     int
+		Amount      int
+		Reason      string
+	}
+	// AdjustBalanceResult は結果です
+	AdjustBalanceResult struct {
+		AdjustmentID string
+		Balance      int
 	}
 )
 
@@ -56,17 +91,141 @@ func (t *dashboard) Approve(id *string) error {
 	if current == nil {
 		return errors.New("dashboard is not found")
 	}
+	previous, err := t.repos.GetLatestClosedDashboard()
+	if err != nil {
+		return err
+	}
+	if err := checkApprovable(t.clock, current, previous); err != nil {
+		return err
+	}
+	missing, err := t.close(current, previous)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errors.New("has not input plan")
+	}
+	if err := t.repos.Approve(current); err != nil {
+		return err
+	}
+
+	t.assetsChangedEvent.Trigger()
+	return nil
+}
+
+// checkApprovable は実績の入力以外の締め処理の前提条件を検証します
+// 締め済みの月は締められず、締め済みの月がある場合は前月が締め済みである必要があります
+func checkApprovable(clock core.Clock, current *models.Dashboard, previous *models.Dashboard) error {
 	if current.State == "closed" {
 		return errors.New("this dashboard is already closed")
 	}
+	if previous != nil {
+		if previous.State != "closed" || !clock.AddMonths(&previous.Date, 1).Equal(current.Date) {
+			return errors.New("previous dashboard is not closed")
+		}
+	}
+	return nil
+}
+
+func (t *dashboard) ApproveThrough(args *ApproveThroughArgs) (*ApproveThroughResult, error) {
+	target := t.clock.GetMonthStartDay(&args.Month)
+	if !target.Before(t.clock.GetMonthStartDay(nil)) {
+		return nil, core.NewError(application.NotEndedMonth)
+	}
+	result, err := t.approveThrough(target, args.Atomic)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.ApprovedMonths) > 0 {
+		t.assetsChangedEvent.Trigger()
+	}
+	return result, nil
+}
+
+// approveThrough は最後に締めた月の翌月から指定月までを順に締めます
+func (t *dashboard) approveThrough(target time.Time, atomic bool) (*ApproveThroughResult, error) {
+	result := &ApproveThroughResult{
+		ApprovedMonths: make([]time.Time, 0),
+		MissingPlanIDs: make([]string, 0),
+	}
 
 	previous, err := t.repos.GetLatestClosedDashboard()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var month time.Time
+	if previous != nil {
+		month = t.clock.AddMonths(&previous.Date, 1)
+	} else {
+		oldest, err := t.repos.GetOldestOpenDashboard()
+		if err != nil {
+			return nil, err
+		}
+		if oldest == nil {
+			return result, nil
+		}
+		month = t.clock.GetMonthStartDay(&oldest.Date)
+	}
+
+	closed := make([]models.Dashboard, 0)
+	for ; !month.After(target); month = t.clock.AddMonths(&month, 1) {
+		m := month
+		current, err := t.getOrCreateByMonth(&m)
+		if err != nil {
+			return nil, err
+		}
+		missing, err := t.close(current, previous)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			result.StoppedMonth = &m
+			result.MissingPlanIDs = missing
+			break
+		}
+		if !atomic {
+			if err := t.repos.Approve(current); err != nil {
+				return nil, err
+			}
+			result.ApprovedMonths = append(result.ApprovedMonths, m)
+		}
+		closed = append(closed, *current)
+		previous = current
+	}
+
+	if atomic {
+		if result.StoppedMonth != nil {
+			return result, nil
+		}
+		if err := t.repos.ApproveAll(&closed); err != nil {
+			return nil, err
+		}
+		for _, dashboard := range closed {
+			result.ApprovedMonths = append(result.ApprovedMonths, dashboard.Date)
+		}
+	}
+	return result, nil
+}
+
+// getOrCreateByMonth は月のダッシュボードを取得し、存在しない場合は作成します
+func (t *dashboard) getOrCreateByMonth(month *time.Time) (*models.Dashboard, error) {
+	current, err := t.repos.GetByMonth(month)
+	if err != nil {
+		return nil, err
 	}
-	if previous != nil && previous.State != "closed" {
-		return errors.New("previous dashboard is not closed")
+	if current != nil {
+		return current, nil
 	}
+	id, err := t.repos.Create(month)
+	if err != nil {
+		return nil, err
+	}
+	return t.repos.GetByID(id)
+}
+
+// close は締め処理の集計をダッシュボードに設定します
+// 実績が未入力の計画がある場合は集計せず、その計画の ID を返します
+func (t *dashboard) close(current *models.Dashboard, previous *models.Dashboard) ([]string, error) {
 	chError := make(chan error)
 
 	chTrn := t.getTransactionsWorker(&current.Date, chError)
@@ -75,7 +234,7 @@ func (t *dashboard) Approve(id *string) error {
 	var plans []models.Plan
 	select {
 	case err := <-chError:
-		return err
+		return nil, err
 	case p := <-chPln:
 		plans = *p
 		break
@@ -84,9 +243,7 @@ func (t *dashboard) Approve(id *string) error {
 	// 集計
 	income := 0
 	expense := 0
-	actualMap := make(map[string]models.Actual)
 	for _, actual := range current.Actual {
-		actualMap[actual.PlanID] = actual
 		if actual.IsIncome {
 			income += actual.ActualAmount
 		} else {
@@ -94,22 +251,22 @@ func (t *dashboard) Approve(id *string) error {
 		}
 	}
 
-	for _, plan := range plans {
-		_, ok := actualMap[plan.PlanID]
-		if !ok {
-			return errors.New("has not input plan")
-		}
+	missing := make([]string, 0)
+	for _, plan := range accountbook.MissingActualPlans(plans, current.Actual) {
+		missing = append(missing, plan.PlanID)
 	}
 
 	var trn []models.Transaction
 	select {
 	case err := <-chError:
-		return err
+		return nil, err
 	case t := <-chTrn:
 		trn = *t
 		break
 	}
-
+	if len(missing) > 0 {
+		return missing, nil
+	}
 	// 取引から集計
 	dMap := make(map[string]*models.Daily)
 	for _, transaction := range trn {
@@ -123,7 +280,7 @@ func (t *dashboard) Approve(id *string) error {
 			}
 			dMap[key] = val
 		}
-		if transaction.Category == 5 {
+		if transaction.Category == accountbook.IncomeCategory {
 			val.Income += transaction.Amount
 			income += transaction.Amount
 		} else {
@@ -145,16 +302,15 @@ func (t *dashboard) Approve(id *string) error {
 		current.PreviousBalance = previous.Balance
 		current.PreviousDashboardID = &previous.DashboardID
 	}
-	balance := *current.PreviousBalance + currentBalance
+	balance := currentBalance + accountbook.SumAdjustments(current.Adjustments)
+	if current.PreviousBalance != nil {
+		balance += *current.PreviousBalance
+	}
 	current.Balance = &balance
 	current.State = "closed"
 	current.Daily = dSlice
-	if err := t.repos.Approve(current); err != nil {
-		return err
-	}
-
-	t.assetsChangedEvent.Trigger()
-	return nil
+	current.Categories = accountbook.BreakdownByCategory(trn)
+	return nil, nil
 }
 func (t *dashboard) getTransactionsWorker(selectedMonth *time.Time, chError chan error) <-chan *[]models.Transaction {
 	ch := make(chan *[]models.Transaction)
@@ -195,13 +351,7 @@ func (t *dashboard) CancelApprove(id *string) error {
 	if err := t.repos.ExistsClosedNext(id); err != nil {
 		return err
 	}
-	current.Balance = nil
-	current.CurrentBalance = nil
-	current.Expense = nil
-	current.Income = nil
-	current.PreviousBalance = nil
-	current.PreviousDashboardID = nil
-	current.State = "open"
+	reopen(current)
 
 	if err := t.repos.CancelApprove(current); err != nil {
 		return err
@@ -210,26 +360,90 @@ func (t *dashboard) CancelApprove(id *string) error {
 	t.assetsChangedEvent.Trigger()
 	return nil
 }
-func (t *dashboard) AdjustBalance(args *AdjustBalanceArgs) error {
+
+// CancelApproveCascade は指定月とそれ以降の締め済みの月をまとめて取り消します
+// Reclose が true の場合は ApproveThrough と同じ処理で前月からの残高を再計算して締め直します
+func (t *dashboard) CancelApproveCascade(args *CancelApproveCascadeArgs) (*CancelApproveCascadeResult, error) {
+	current, err := t.repos.GetByID(&args.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New("dashboard is not found")
+	}
+	if current.State != "closed" {
+		return nil, errors.New("this dashboard is not closed")
+	}
+
+	dashboards, err := t.repos.CancelApproveCascade(&args.DashboardID, reopen)
+	if err != nil {
+		return nil, err
+	}
+	t.assetsChangedEvent.Trigger()
+
+	result := &CancelApproveCascadeResult{
+		ReopenedMonths: make([]time.Time, len(*dashboards)),
+		ReclosedMonths: make([]time.Time, 0),
+		MissingPlanIDs: make([]string, 0),
+	}
+	for i, model := range *dashboards {
+		result.ReopenedMonths[i] = model.Date
+	}
+	if !args.Reclose || len(result.ReopenedMonths) == 0 {
+		return result, nil
+	}
+
+	last := result.ReopenedMonths[len(result.ReopenedMonths)-1]
+	approved, err := t.approveThrough(t.clock.GetMonthStartDay(&last), false)
+	if err != nil {
+		return nil, err
+	}
+	result.ReclosedMonths = approved.ApprovedMonths
+	result.StoppedMonth = approved.StoppedMonth
+	result.MissingPlanIDs = approved.MissingPlanIDs
+	if len(result.ReclosedMonths) > 0 {
+		t.assetsChangedEvent.Trigger()
+	}
+	return result, nil
+}
+
+// reopen はダッシュボードの締めの集計を取り消します
+func reopen(current *models.Dashboard) {
+	current.Balance = nil
+	current.CurrentBalance = nil
+	current.Expense = nil
+	current.Income = nil
+	current.PreviousBalance = nil
+	current.PreviousDashboardID = nil
+	current.State = "open"
+}
+func (t *dashboard) AdjustBalance(args *AdjustBalanceArgs) (*AdjustBalanceResult, error) {
 	id := &args.DashboardID
 	current, err := t.repos.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if current == nil {
-		return errors.New("dashboard is not found")
+		return nil, errors.New("dashboard is not found")
 	}
 	if current.State != "closed" {
-		return errors.New("this dashboard is not closed")
+		return nil, errors.New("this dashboard is not closed")
 	}
 	if err := t.repos.ExistsClosedNext(id); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := t.repos.AdjustBalance(id, args.Balance); err != nil {
-		return err
+	adjustmentID, balance, err := t.repos.AddAdjustment(id, &models.Adjustment{
+		Amount: args.Amount,
+		Reason: args.Reason,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	t.assetsChangedEvent.Trigger()
-	return nil
+	return &AdjustBalanceResult{
+		AdjustmentID: *adjustmentID,
+		Balance:      balance,
+	}, nil
 }
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	digests struct {
+		repos             application.UsersRepository
+		transactionsRepos application.TransactionsRepository
+		plansRepos        application.PlansRepository
+		dashboardRepos    application.DashboardRepository
+		weeklyMail        application.WeeklyDigestMail
+		monthEndMail      application.MonthEndDigestMail
+		clock             core.Clock
+	}
+	// Digests is DigestsService
+	Digests interface {
+		Send() (*SendDigestsResult, error)
+	}
+	// SendDigestsResult は結果です
+	SendDigestsResult struct {
+		Weekly   bool
+		MonthEnd bool
+	}
+)
+
+// NewDigests is create instance
+func NewDigests(
+	repos application.UsersRepository,
+	transactionsRepos application.TransactionsRepository,
+	plansRepos application.PlansRepository,
+	dashboardRepos application.DashboardRepository,
+	weeklyMail application.WeeklyDigestMail,
+	monthEndMail application.MonthEndDigestMail,
+	clock core.Clock,
+) Digests {
+	return &digests{
+		repos,
+		transactionsRepos,
+		plansRepos,
+		dashboardRepos,
+		weeklyMail,
+		monthEndMail,
+		clock,
+	}
+}
+
+// Send はユーザーの設定に従い、今日送るまとめメールを送ります
+// 週次のまとめは指定した曜日に、月末のお知らせは月の最終日に送り、同じ日には再送しません
+// 送信した日時はメール毎に記録し、他方の送信に失敗しても送信済みのメールは再送しません
+func (t *digests) Send() (*SendDigestsResult, error) {
+	user, err := t.repos.GetByAuth()
+	if err != nil {
+		return nil, err
+	}
+	now := t.clock.Now()
+	today := t.clock.GetDay(&now)
+	result := &SendDigestsResult{}
+
+	if user.WeeklyDigest && int(today.Weekday()) == user.DigestWeekday && !t.sentOn(user.LastWeeklyDigestAt, today) {
+		if err := t.sendWeekly(user, today); err != nil {
+			return nil, err
+		}
+		user.LastWeeklyDigestAt = &now
+		if err := t.repos.UpdateDigestSentAt(user); err != nil {
+			return nil, err
+		}
+		result.Weekly = true
+	}
+	month := t.clock.GetMonthStartDay(nil)
+	monthEnd := t.clock.AddMonths(&month, 1).Add(-time.Nanosecond)
+	lastDay := t.clock.GetDay(&monthEnd)
+	if user.MonthEndDigest && today.Equal(lastDay) && !t.sentOn(user.LastMonthEndDigestAt, today) {
+		sent, err := t.sendMonthEnd(user, month)
+		if err != nil {
+			return nil, err
+		}
+		if sent {
+			user.LastMonthEndDigestAt = &now
+			if err := t.repos.UpdateDigestSentAt(user); err != nil {
+				return nil, err
+			}
+			result.MonthEnd = true
+		}
+	}
+	return result, nil
+}
+func (t *digests) sentOn(sentAt *time.Time, today time.Time) bool {
+	return sentAt != nil && t.clock.GetDay(sentAt).Equal(today)
+}
+
+// sendWeekly は前日までの 1 週間の支出を、前月の同じ週の支出と比べて送ります
+func (t *digests) sendWeekly(user *models.User, today time.Time) error {
+	start := today.AddDate(0, 0, -7)
+	previousStart := start.AddDate(0, -1, 0)
+	previousEnd := today.AddDate(0, -1, 0)
+	transactions, err := t.transactionsRepos.GetByDateRange(&previousStart, &today)
+	if err != nil {
+		return err
+	}
+	return t.weeklyMail.Send(&application.WeeklyDigestMailSendArgs{
+		Email:           user.Email,
+		Start:           start,
+		End:             today,
+		Expense:         accountbook.SumExpense(*transactions, start, today),
+		PreviousExpense: accountbook.SumExpense(*transactions, previousStart, previousEnd),
+	})
+}
+
+// sendMonthEnd は実績が未入力の計画と、当月を締められるかどうかを送ります
+// 締められるかどうかは締め処理と同じく、前月が締め済みであることも条件とします
+// 既に締めた月の場合は送りません
+func (t *digests) sendMonthEnd(user *models.User, month time.Time) (bool, error) {
+	current, err := t.dashboardRepos.GetByMonth(&month)
+	if err != nil {
+		return false, err
+	}
+	if current == nil {
+		current = &models.Dashboard{Date: month}
+	}
+	if current.State == "closed" {
+		return false, nil
+	}
+	plans, err := t.plansRepos.GetByMonth(&month)
+	if err != nil {
+		return false, err
+	}
+	previous, err := t.dashboardRepos.GetLatestClosedDashboard()
+	if err != nil {
+		return false, err
+	}
+	missing := accountbook.MissingActualPlans(*plans, current.Actual)
+	names := make([]string, len(missing))
+	for i, plan := range missing {
+		names[i] = plan.PlanName
+	}
+	if err := t.monthEndMail.Send(&application.MonthEndDigestMailSendArgs{
+		Email:            user.Email,
+		Month:            month,
+		MissingPlanNames: names,
+		CanApprove:       len(missing) == 0 && checkApprovable(t.clock, current, previous) == nil,
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	payees struct {
+		repos application.PayeesRepository
+	}
+	// Payees is PayeesService
+	Payees interface {
+		Create(args *PayeeArgs) (*CreatePayeeResult, error)
+		Update(id *string, args *PayeeArgs) error
+		Delete(id *string) error
+	}
+	// PayeeArgs は引数です
+	PayeeArgs struct {
+		PayeeName string
+	}
+	// CreatePayeeResult は結果です
+	CreatePayeeResult struct {
+		PayeeID string
+	}
+)
+
+// NewPayees is create instance
+func NewPayees(repos application.PayeesRepository) Payees {
+	return &payees{repos}
+}
+func (t *payees) Create(args *PayeeArgs) (*CreatePayeeResult, error) {
+	id, err := t.repos.Create(&models.Payee{PayeeName: args.PayeeName})
+	if err != nil {
+		return nil, err
+	}
+	return &CreatePayeeResult{PayeeID: *id}, nil
+}
+func (t *payees) Update(id *string, args *PayeeArgs) error {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return err
+	}
+	model.PayeeName = args.PayeeName
+	return t.repos.Update(id, model)
+}
+func (t *payees) Delete(id *string) error {
+	if _, err := t.repos.GetByID(id); err != nil {
+		return err
+	}
+	return t.repos.Delete(id)
+}
@@ -13,6 +13,7 @@ import (
 type (
 	plans struct {
 		repos              application.PlansRepository
+		dashboardRepos     application.DashboardRepository
 		clock              core.Clock
 		assetsChangedEvent accountbook.AssetsChangedEvent
 	}
@@ -21,6 +22,8 @@ type (
 		Create(args *PlanArgs) (*CreatePlanResult, error)
 		Update(id *string, args *PlanArgs) error
 		Remove(id *string) error
+		Restore(id *string) error
+		MigrateRecurrences() (*MigrateRecurrencesResult, error)
 	}
 	// PlanArgs は引数です
 	PlanArgs struct {
@@ -28,22 +31,30 @@ type (
 		IsIncome   bool
 		PlanAmount int
 		Interval   int
+		Recurrence *models.Recurrence
 		Start      *time.Time
 		End        *time.Time
+		// EffectiveFrom は金額などの改定の適用開始月です
+		EffectiveFrom *time.Time
 	}
 	// CreatePlanResult は結果です
 	CreatePlanResult struct {
 		PlanID string
 	}
+	// MigrateRecurrencesResult は結果です
+	MigrateRecurrencesResult struct {
+		Count int
+	}
 )
 
 // NewPlans is create instance
 func NewPlans(
 	repos application.PlansRepository,
+	dashboardRepos application.DashboardRepository,
 	clock core.Clock,
 	assetsChangedEvent accountbook.AssetsChangedEvent,
 ) Plans {
-	return &plans{repos, clock, assetsChangedEvent}
+	return &plans{repos, dashboardRepos, clock, assetsChangedEvent}
 }
 func (t *plans) Create(args *PlanArgs) (*CreatePlanResult, error) {
 	id, err := t.repos.Create(args.convert(t.clock.Now()))
@@ -54,14 +65,28 @@ func (t *plans) Create(args *PlanArgs) (*CreatePlanResult, error) {
 	return &CreatePlanResult{PlanID: *id}, nil
 }
 func (t *PlanArgs) convert(now time.Time) *models.Plan {
+	recurrence := t.recurrence()
 	return &models.Plan{
 		PlanName:   t.PlanName,
 		IsIncome:   t.IsIncome,
 		PlanAmount: t.PlanAmount,
-		Interval:   t.Interval,
+		Interval:   accountbook.LegacyInterval(recurrence),
+		Recurrence: recurrence,
 		Start:      t.Start,
 		End:        t.End,
+		Revisions: []models.PlanRevision{{
+			PlanName:   t.PlanName,
+			PlanAmount: t.PlanAmount,
+			IsIncome:   t.IsIncome,
+			CreatedAt:  now,
+		}},
+	}
+}
+func (t *PlanArgs) recurrence() *models.Recurrence {
+	if t.Recurrence != nil {
+		return t.Recurrence
 	}
+	return accountbook.RecurrenceOf(&models.Plan{Interval: t.Interval})
 }
 func (t *plans) Update(id *string, args *PlanArgs) error {
 	model, err := t.repos.GetByID(id)
@@ -72,10 +97,18 @@ func (t *plans) Update(id *string, args *PlanArgs) error {
 		return core.NewError(application.IsDeleted)
 	}
 
-	model.PlanName = args.PlanName
-	model.IsIncome = args.IsIncome
-	model.PlanAmount = args.PlanAmount
-	model.Interval = args.Interval
+	now := t.clock.Now()
+	accountbook.AddRevision(model, models.PlanRevision{
+		EffectiveFrom: t.clock.GetMonthStartDay(args.EffectiveFrom),
+		PlanName:      args.PlanName,
+		PlanAmount:    args.PlanAmount,
+		IsIncome:      args.IsIncome,
+		CreatedAt:     now,
+	})
+	accountbook.ApplyRevision(model, now)
+	recurrence := args.recurrence()
+	model.Interval = accountbook.LegacyInterval(recurrence)
+	model.Recurrence = recurrence
 	model.Start = args.Start
 	model.End = args.End
 
@@ -94,9 +127,93 @@ func (t *plans) Remove(id *string) error {
 		return core.NewError(application.IsDeleted)
 	}
 	model.IsDeleted = true
+	now := t.clock.Now()
+	model.DeletedAt = &now
+	if err := t.repos.Update(id, model); err != nil {
+		return err
+	}
+	t.assetsChangedEvent.Trigger()
+	return nil
+}
+func (t *plans) Restore(id *string) error {
+	model, err := t.repos.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !model.IsDeleted {
+		return core.NewError(application.NotDeleted)
+	}
+	conflicts, err := t.conflictsWithClosedMonth(model)
+	if err != nil {
+		return err
+	}
+	if conflicts {
+		return core.NewError(application.ConflictsWithClosedMonth)
+	}
+	model.IsDeleted = false
+	model.DeletedAt = nil
 	if err := t.repos.Update(id, model); err != nil {
 		return err
 	}
 	t.assetsChangedEvent.Trigger()
 	return nil
 }
+
+// conflictsWithClosedMonth は削除中に締められた月のうち、計画が発生するのに実績がない月があるかを返します
+func (t *plans) conflictsWithClosedMonth(model *models.Plan) (bool, error) {
+	// 削除日時がない旧データは計画の開始から確認します
+	start := accountbook.PlanStart(model)
+	if model.DeletedAt != nil {
+		start = *model.DeletedAt
+	}
+	from := t.clock.GetMonthStartDay(&start)
+	latest, err := t.dashboardRepos.GetLatestClosedDashboard()
+	if err != nil {
+		return false, err
+	}
+	if latest == nil {
+		return false, nil
+	}
+	for month := t.clock.GetMonthStartDay(&latest.Date); !month.Before(from); month = t.clock.AddMonths(&month, -1) {
+		if len(accountbook.Occurrences(model, month, t.clock.AddMonths(&month, 1))) == 0 {
+			continue
+		}
+		dashboard, err := t.dashboardRepos.GetByMonth(&month)
+		if err != nil {
+			return false, err
+		}
+		if dashboard == nil || dashboard.State != "closed" {
+			continue
+		}
+		captured := false
+		for _, actual := range dashboard.Actual {
+			if actual.PlanID == model.PlanID {
+				captured = true
+				break
+			}
+		}
+		if !captured {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (t *plans) MigrateRecurrences() (*MigrateRecurrencesResult, error) {
+	// 削除した計画も復元すると使われるため、合わせて移行します
+	plans, err := t.repos.GetIncludeDeleted()
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	for _, plan := range *plans {
+		p := plan
+		if !accountbook.MigrateRecurrence(&p) {
+			continue
+		}
+		if err := t.repos.Update(&p.PlanID, &p); err != nil {
+			return nil, err
+		}
+		count++
+	}
+	return &MigrateRecurrencesResult{Count: count}, nil
+}
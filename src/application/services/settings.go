@@ -0,0 +1,67 @@
+package services
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	settings struct {
+		repos          application.UsersRepository
+		claimsProvider core.ClaimsProvider
+	}
+	// Settings is SettingsService
+	Settings interface {
+		UpdateMonthStartDay(monthStartDay int) error
+		UpdateTimeZone(timeZone string) error
+		UpdateDigest(args *DigestSettingsArgs) error
+	}
+	// DigestSettingsArgs は引数です
+	DigestSettingsArgs struct {
+		WeeklyDigest   bool
+		DigestWeekday  int
+		MonthEndDigest bool
+	}
+)
+
+// NewSettings is create instance
+func NewSettings(
+	repos application.UsersRepository,
+	claimsProvider core.ClaimsProvider,
+) Settings {
+	return &settings{repos, claimsProvider}
+}
+
+// UpdateMonthStartDay は月の開始日を変更します
+// ダッシュボードと集計は月の開始日時で検索するため、実績を入力中の月を新しい区切りに移します
+func (t *settings) UpdateMonthStartDay(monthStartDay int) error {
+	return t.changeMonthBoundary(func(user *models.User) {
+		user.MonthStartDay = monthStartDay
+	})
+}
+
+// UpdateTimeZone はタイムゾーンを変更します
+// 月の開始日が同じでも月の区切りの日時が変わるため、月の開始日とは別に実績を入力中の月を移します
+func (t *settings) UpdateTimeZone(timeZone string) error {
+	return t.changeMonthBoundary(func(user *models.User) {
+		user.TimeZone = timeZone
+	})
+}
+func (t *settings) changeMonthBoundary(apply func(user *models.User)) error {
+	tokenTimeZone := *t.claimsProvider.GetTimeZone()
+	return t.repos.ModifyMonthBoundary(func(boundary *models.MonthBoundary) {
+		accountbook.ChangeMonthBoundary(boundary, apply, tokenTimeZone)
+	})
+}
+func (t *settings) UpdateDigest(args *DigestSettingsArgs) error {
+	model, err := t.repos.GetByAuth()
+	if err != nil {
+		return err
+	}
+	model.WeeklyDigest = args.WeeklyDigest
+	model.DigestWeekday = args.DigestWeekday
+	model.MonthEndDigest = args.MonthEndDigest
+	return t.repos.UpdateDigestSettings(model)
+}
@@ -13,6 +13,9 @@ import (
 type (
 	transactions struct {
 		repos              application.TransactionsRepository
+		rulesRepos         application.CategorizationRulesRepository
+		payeesRepos        application.PayeesRepository
+		blobStore          application.BlobStore
 		clock              core.Clock
 		assetsChangedEvent accountbook.AssetsChangedEvent
 	}
@@ -21,42 +24,113 @@ type (
 		Create(args *TransactionArgs) (*CreateTransactionResult, error)
 		Update(id *string, args *TransactionArgs) error
 		Delete(id *string) error
+		Batch(args *[]BatchTransactionArgs) (*[]BatchTransactionResult, error)
+		Merge(args *MergeTransactionsArgs) error
 	}
 	// TransactionArgs は引数です
+	// Category は登録時のみ省略でき、省略した場合は未分類とします
 	TransactionArgs struct {
-		Amount   int
-		Category int
-		Notes    *string
+		Amount          int
+		Category        *int
+		Notes           *string
+		ImportReference *string
+		ImportSource    *string
+		PayeeID         *string
+		Tags            []string
 	}
 	// CreateTransactionResult は結果です
 	CreateTransactionResult struct {
-		TransactionID string
+		TransactionID           string
+		DuplicateTransactionIDs []string
+	}
+	// MergeTransactionsArgs は引数です
+	MergeTransactionsArgs struct {
+		TransactionID       string
+		MergeTransactionIDs []string
+	}
+	// BatchTransactionArgs は一括操作の1件分の引数です
+	BatchTransactionArgs struct {
+		Operation     application.BatchOperation
+		TransactionID *string
+		Args          *TransactionArgs
+	}
+	// BatchTransactionResult は一括操作の1件分の結果です
+	BatchTransactionResult struct {
+		TransactionID *string
+		Error         core.Error
 	}
 )
 
 // NewTransactions is create instance
 func NewTransactions(
 	repos application.TransactionsRepository,
+	rulesRepos application.CategorizationRulesRepository,
+	payeesRepos application.PayeesRepository,
+	blobStore application.BlobStore,
 	clock core.Clock,
 	assetsChangedEvent accountbook.AssetsChangedEvent,
 ) Transactions {
-	return &transactions{repos, clock, assetsChangedEvent}
+	return &transactions{
+		repos,
+		rulesRepos,
+		payeesRepos,
+		blobStore,
+		clock,
+		assetsChangedEvent,
+	}
 }
 func (t *transactions) Create(args *TransactionArgs) (*CreateTransactionResult, error) {
-	id, err := t.repos.Create(args.convert(t.clock.Now()))
+	model := args.convert(t.clock.Now())
+	categorizer, err := newCategorizer(t.rulesRepos, t.payeesRepos)
+	if err != nil {
+		return nil, err
+	}
+	categorizer.categorize(model)
+	duplicateIDs, err := t.findDuplicates(model)
+	if err != nil {
+		return nil, err
+	}
+	id, err := t.repos.Create(model)
 	if err != nil {
 		return nil, err
 	}
 	t.assetsChangedEvent.Trigger()
-	return &CreateTransactionResult{TransactionID: *id}, nil
+	return &CreateTransactionResult{
+		TransactionID:           *id,
+		DuplicateTransactionIDs: duplicateIDs,
+	}, nil
 }
 func (t *TransactionArgs) convert(now time.Time) *models.Transaction {
-	return &models.Transaction{
-		Amount:   t.Amount,
-		Category: t.Category,
-		Notes:    t.Notes,
-		Date:     now,
+	model := &models.Transaction{
+		Amount:          t.Amount,
+		Uncategorized:   t.Category == nil,
+		Notes:           t.Notes,
+		ImportReference: t.ImportReference,
+		ImportSource:    t.ImportSource,
+		PayeeID:         t.PayeeID,
+		Tags:            t.Tags,
+		Date:            now,
+	}
+	if t.Category != nil {
+		model.Category = *t.Category
 	}
+	return model
+}
+func (t *transactions) findDuplicates(model *models.Transaction) ([]string, error) {
+	start := t.clock.GetDay(&model.Date).AddDate(0, 0, -accountbook.DefaultDuplicateDays)
+	end := t.clock.GetDay(&model.Date).AddDate(0, 0, 1)
+	candidates, err := t.repos.GetByDateRange(&start, &end)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0)
+	for _, candidate := range *candidates {
+		c := candidate
+		if accountbook.IsDuplicate(model, &c, accountbook.DefaultDuplicateDays) {
+			ids = append(ids, c.TransactionID)
+		}
+	}
+	return ids, nil
 }
 func (t *transactions) Update(id *string, args *TransactionArgs) error {
 	model, err := t.repos.Get(id)
@@ -68,8 +142,13 @@ func (t *transactions) Update(id *string, args *TransactionArgs) error {
 	}
 
 	model.Amount = args.Amount
-	model.Category = args.Category
+	model.Category = *args.Category
+	model.Uncategorized = false
 	model.Notes = args.Notes
+	model.ImportReference = args.ImportReference
+	model.ImportSource = args.ImportSource
+	model.PayeeID = args.PayeeID
+	model.Tags = args.Tags
 
 	if err := t.repos.Update(id, model); err != nil {
 		return err
@@ -90,5 +169,123 @@ func (t *transactions) Delete(id *string) error {
 		return err
 	}
 	t.assetsChangedEvent.Trigger()
+	return purgeAttachments(t.repos, t.blobStore, id)
+}
+func (t *transactions) Batch(args *[]BatchTransactionArgs) (*[]BatchTransactionResult, error) {
+	now := t.clock.Now()
+	categorizer, err := newCategorizer(t.rulesRepos, t.payeesRepos)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]BatchTransactionResult, len(*args))
+	items := make([]application.TransactionsBatchItem, 0)
+	indexes := make([]int, 0)
+	for i, arg := range *args {
+		item := application.TransactionsBatchItem{
+			Operation:     arg.Operation,
+			TransactionID: arg.TransactionID,
+		}
+		if arg.Operation == application.BatchCreate {
+			item.Model = arg.Args.convert(now)
+			categorizer.categorize(item.Model)
+		} else {
+			model, err := t.repos.Get(arg.TransactionID)
+			if err != nil {
+				if cErr, ok := err.(core.Error); ok {
+					results[i].Error = cErr
+					continue
+				}
+				return nil, err
+			}
+			if model.DailyID != nil {
+				results[i].Error = core.NewError(application.ClosedTransaction)
+				continue
+			}
+			if arg.Operation == application.BatchUpdate {
+				model.Amount = arg.Args.Amount
+				model.Category = *arg.Args.Category
+				model.Uncategorized = false
+				model.Notes = arg.Args.Notes
+				model.ImportReference = arg.Args.ImportReference
+				model.ImportSource = arg.Args.ImportSource
+				model.PayeeID = arg.Args.PayeeID
+				model.Tags = arg.Args.Tags
+			}
+			item.Model = model
+		}
+		items = append(items, item)
+		indexes = append(indexes, i)
+	}
+	if len(items) == 0 {
+		return &results, nil
+	}
+
+	ids, err := t.repos.Batch(&items)
+	if err != nil {
+		return nil, err
+	}
+	for j, id := range *ids {
+		transactionID := id
+		results[indexes[j]].TransactionID = &transactionID
+	}
+	t.assetsChangedEvent.Trigger()
+	for _, item := range items {
+		if item.Operation == application.BatchDelete {
+			if err := purgeAttachments(t.repos, t.blobStore, item.TransactionID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &results, nil
+}
+func (t *transactions) Merge(args *MergeTransactionsArgs) error {
+	model, err := t.repos.Get(&args.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	items := make([]application.TransactionsBatchItem, 0)
+	for _, mergeID := range args.MergeTransactionIDs {
+		id := mergeID
+		merged, err := t.repos.Get(&id)
+		if err != nil {
+			return err
+		}
+		if merged.DailyID != nil {
+			return core.NewError(application.ClosedTransaction)
+		}
+		if merged.Amount != model.Amount || merged.Category != model.Category || merged.Uncategorized != model.Uncategorized {
+			return core.NewError(application.NotDuplicateTransaction)
+		}
+		// 残す取引に足りない情報を補う
+		if model.Notes == nil || *model.Notes == "" {
+			model.Notes = merged.Notes
+		}
+		if model.ImportReference == nil {
+			model.ImportReference = merged.ImportReference
+		}
+		items = append(items, application.TransactionsBatchItem{
+			Operation:     application.BatchDelete,
+			TransactionID: &id,
+		})
+	}
+	if model.DailyID == nil {
+		items = append(items, application.TransactionsBatchItem{
+			Operation:     application.BatchUpdate,
+			TransactionID: &args.TransactionID,
+			Model:         model,
+		})
+	}
+
+	if _, err := t.repos.Batch(&items); err != nil {
+		return err
+	}
+	t.assetsChangedEvent.Trigger()
+	for _, mergeID := range args.MergeTransactionIDs {
+		id := mergeID
+		if err := moveAttachments(t.repos, t.blobStore, &id, &args.TransactionID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	aggregates struct {
+		service services.Aggregates
+	}
+	// Aggregates is AggregatesUseCases
+	Aggregates interface {
+		Repair(args *RepairAggregatesArgs) (*RepairAggregatesResult, error)
+	}
+	// RepairAggregatesArgs は引数です
+	RepairAggregatesArgs struct {
+		From time.Time
+		To   time.Time
+	}
+	// RepairAggregatesResult は結果です
+	RepairAggregatesResult struct {
+		Months []time.Time
+	}
+)
+
+// NewAggregates is create instance
+func NewAggregates(service services.Aggregates) Aggregates {
+	return &aggregates{service}
+}
+func (t *aggregates) Repair(args *RepairAggregatesArgs) (*RepairAggregatesResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	res, err := t.service.Repair(&services.RepairAggregatesArgs{
+		From: args.From,
+		To:   args.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &RepairAggregatesResult{Months: res.Months}, nil
+}
+func (t *RepairAggregatesArgs) valid() error {
+	if t.From.After(t.To) {
+		return core.NewError(application.InValidDateRange)
+	}
+	months := (t.To.Year()-t.From.Year())*12 + int(t.To.Month()) - int(t.From.Month()) + 1
+	if months > MaxReportMonths {
+		return core.NewError(application.TooLongPeriod)
+	}
+	return nil
+}
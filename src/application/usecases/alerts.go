@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+)
+
+type (
+	alerts struct {
+		service services.Alerts
+	}
+	// Alerts is AlertsUseCases
+	Alerts interface {
+		Evaluate() (*EvaluateAlertsResult, error)
+	}
+	// EvaluateAlertsResult は結果です
+	EvaluateAlertsResult struct {
+		NotificationRuleIDs []string
+	}
+)
+
+// NewAlerts is create instance
+func NewAlerts(service services.Alerts) Alerts {
+	return &alerts{service}
+}
+func (t *alerts) Evaluate() (*EvaluateAlertsResult, error) {
+	res, err := t.service.Evaluate()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(res.Notifications))
+	for i, notification := range res.Notifications {
+		ids[i] = *notification.NotificationRuleID
+	}
+	return &EvaluateAlertsResult{NotificationRuleIDs: ids}, nil
+}
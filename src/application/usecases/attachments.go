@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	attachments struct {
+		query   AttachmentsQuery
+		service services.Attachments
+	}
+	// Attachments is AttachmentsUseCases
+	Attachments interface {
+		GetAttachments(transactionID *string) (*GetAttachmentsResult, error)
+		GetAttachment(args *GetAttachmentArgs) (*GetAttachmentResult, error)
+		Upload(args *UploadAttachmentArgs) (*UploadAttachmentResult, error)
+		Delete(transactionID *string, id *string) error
+	}
+	// GetAttachmentsResult は結果です
+	GetAttachmentsResult struct {
+		Attachments []AttachmentResult
+	}
+	// AttachmentResult は結果です
+	AttachmentResult struct {
+		AttachmentID string
+		FileName     string
+		ContentType  string
+		Size         int
+		HasThumbnail bool
+		CreatedAt    time.Time
+	}
+	// GetAttachmentArgs は引数です
+	GetAttachmentArgs struct {
+		TransactionID string
+		AttachmentID  string
+		Thumbnail     bool
+	}
+	// GetAttachmentResult は結果です
+	GetAttachmentResult struct {
+		FileName    string
+		ContentType string
+		Data        []byte
+	}
+	// UploadAttachmentArgs は引数です
+	UploadAttachmentArgs struct {
+		TransactionID string
+		FileName      string
+		Data          []byte
+	}
+	// UploadAttachmentResult は結果です
+	UploadAttachmentResult struct {
+		AttachmentID string
+	}
+)
+
+// MaxAttachmentSize は添付ファイルの上限サイズです
+const MaxAttachmentSize = 10 * 1024 * 1024
+
+// supportedContentTypes は添付ファイルとして受け付ける形式です
+var supportedContentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"application/pdf",
+}
+
+// NewAttachments is create instance
+func NewAttachments(
+	query AttachmentsQuery,
+	service services.Attachments,
+) Attachments {
+	return &attachments{
+		query,
+		service,
+	}
+}
+func (t *attachments) GetAttachments(transactionID *string) (*GetAttachmentsResult, error) {
+	return t.query.GetAttachments(transactionID)
+}
+func (t *attachments) GetAttachment(args *GetAttachmentArgs) (*GetAttachmentResult, error) {
+	return t.query.GetAttachment(args)
+}
+func (t *attachments) Upload(args *UploadAttachmentArgs) (*UploadAttachmentResult, error) {
+	contentType, err := args.valid()
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.service.Upload(&services.UploadAttachmentArgs{
+		TransactionID: args.TransactionID,
+		FileName:      args.FileName,
+		ContentType:   *contentType,
+		Data:          args.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UploadAttachmentResult{AttachmentID: res.AttachmentID}, nil
+}
+
+// valid は入力を検証し、内容から判定したファイル形式を返します
+func (t *UploadAttachmentArgs) valid() (*string, error) {
+	err := core.NewError()
+	if t.TransactionID == "" {
+		err.Append(application.RequiredID)
+	}
+	if len(t.Data) == 0 {
+		err.Append(application.RequiredFile)
+		return nil, err
+	}
+	if len(t.Data) > MaxAttachmentSize {
+		err.Append(application.TooLargeFile)
+	}
+	contentType := strings.Split(http.DetectContentType(t.Data), ";")[0]
+	if !isSupportedContentType(contentType) {
+		err.Append(application.NotSupportedContentType)
+	}
+	if err.HasError() {
+		return nil, err
+	}
+	return &contentType, nil
+}
+func isSupportedContentType(contentType string) bool {
+	for _, supported := range supportedContentTypes {
+		if contentType == supported {
+			return true
+		}
+	}
+	return false
+}
+func (t *attachments) Delete(transactionID *string, id *string) error {
+	return t.service.Delete(transactionID, id)
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	budgets struct {
+		query   BudgetsQuery
+		service services.Budgets
+	}
+	// Budgets is BudgetsUseCases
+	Budgets interface {
+		GetBudgets() (*GetBudgetsResult, error)
+		GetBudget(id *string) (*GetBudgetResult, error)
+		GetBudgetReport(args *GetBudgetReportArgs) (*GetBudgetReportResult, error)
+		Create(args *BudgetArgs) (*CreateBudgetResult, error)
+		Update(id *string, args *BudgetArgs) error
+		Delete(id *string) error
+	}
+	// GetBudgetsResult は結果です
+	GetBudgetsResult struct {
+		Budgets []GetBudgetResult
+	}
+	// GetBudgetResult は結果です
+	GetBudgetResult struct {
+		BudgetID string
+		Category int
+		Month    time.Time
+		Amount   int
+		Rollover bool
+	}
+	// BudgetArgs は引数です
+	BudgetArgs struct {
+		Category *int
+		Month    *time.Time
+		Amount   int
+		Rollover bool
+	}
+	// CreateBudgetResult は結果です
+	CreateBudgetResult struct {
+		BudgetID string
+	}
+	// GetBudgetReportArgs は引数です
+	GetBudgetReportArgs struct {
+		SelectedMonth time.Time
+	}
+	// GetBudgetReportResult は結果です
+	GetBudgetReportResult struct {
+		SelectedMonth time.Time
+		Categories    []BudgetReportCategoryResult
+	}
+	// BudgetReportCategoryResult はカテゴリ毎の予算と実績です
+	BudgetReportCategoryResult struct {
+		Category  int
+		BudgetID  *string
+		Budgeted  int
+		Rollover  int
+		Spent     int
+		Remaining int
+	}
+)
+
+// NewBudgets is create instance
+func NewBudgets(
+	query BudgetsQuery,
+	service services.Budgets,
+) Budgets {
+	return &budgets{
+		query,
+		service,
+	}
+}
+func (t *budgets) GetBudgets() (*GetBudgetsResult, error) {
+	return t.query.GetBudgets()
+}
+func (t *budgets) GetBudget(id *string) (*GetBudgetResult, error) {
+	return t.query.GetBudget(id)
+}
+func (t *budgets) GetBudgetReport(args *GetBudgetReportArgs) (*GetBudgetReportResult, error) {
+	return t.query.GetBudgetReport(args)
+}
+func (t *budgets) Create(args *BudgetArgs) (*CreateBudgetResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	res, err := t.service.Create(args.convert())
+	if err != nil {
+		return nil, err
+	}
+	return &CreateBudgetResult{BudgetID: res.BudgetID}, nil
+}
+func (t *BudgetArgs) valid() error {
+	err := core.NewError()
+	if t.Category == nil {
+		err.Append(application.RequiredCategory)
+	}
+	if t.Month == nil {
+		err.Append(application.RequiredMonth)
+	}
+	if t.Amount <= 0 {
+		err.Append(application.MoreThanZeroAmount)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+func (t *BudgetArgs) convert() *services.BudgetArgs {
+	return &services.BudgetArgs{
+		Category: *t.Category,
+		Month:    *t.Month,
+		Amount:   t.Amount,
+		Rollover: t.Rollover,
+	}
+}
+func (t *budgets) Update(id *string, args *BudgetArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	return t.service.Update(id, args.convert())
+}
+func (t *budgets) Delete(id *string) error {
+	return t.service.Delete(id)
+}
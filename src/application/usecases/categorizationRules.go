@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+type (
+	categorizationRules struct {
+		query   CategorizationRulesQuery
+		service services.CategorizationRules
+	}
+	// CategorizationRules is CategorizationRulesUseCases
+	CategorizationRules interface {
+		GetCategorizationRules() (*GetCategorizationRulesResult, error)
+		GetCategorizationRule(id *string) (*GetCategorizationRuleResult, error)
+		Create(args *CategorizationRuleArgs) (*CreateCategorizationRuleResult, error)
+		Update(id *string, args *CategorizationRuleArgs) error
+		Delete(id *string) error
+		Apply(args *ApplyCategorizationRulesArgs) (*ApplyCategorizationRulesResult, error)
+	}
+	// GetCategorizationRulesResult は結果です
+	GetCategorizationRulesResult struct {
+		CategorizationRules []GetCategorizationRuleResult
+	}
+	// GetCategorizationRuleResult は結果です
+	GetCategorizationRuleResult struct {
+		RuleID string
+		CategorizationRuleArgs
+	}
+	// CategorizationRuleArgs は引数です
+	CategorizationRuleArgs struct {
+		RuleName     string
+		Priority     int
+		NotesPattern *string
+		PayeePattern *string
+		MinAmount    *int
+		MaxAmount    *int
+		ImportSource *string
+		Category     *int
+		Tags         []string
+		PayeeID      *string
+	}
+	// CreateCategorizationRuleResult は結果です
+	CreateCategorizationRuleResult struct {
+		RuleID string
+	}
+	// ApplyCategorizationRulesArgs は引数です
+	ApplyCategorizationRulesArgs struct {
+		SelectedMonth time.Time
+		Preview       bool
+	}
+	// ApplyCategorizationRulesResult は結果です
+	ApplyCategorizationRulesResult struct {
+		Changes []CategorizationChangeResult
+	}
+	// CategorizationChangeResult は結果です
+	CategorizationChangeResult struct {
+		TransactionID  string
+		BeforeCategory int
+		Category       int
+		PayeeID        *string
+		Tags           []string
+	}
+)
+
+// NewCategorizationRules is create instance
+func NewCategorizationRules(
+	query CategorizationRulesQuery,
+	service services.CategorizationRules,
+) CategorizationRules {
+	return &categorizationRules{
+		query,
+		service,
+	}
+}
+func (t *categorizationRules) GetCategorizationRules() (*GetCategorizationRulesResult, error) {
+	return t.query.GetCategorizationRules()
+}
+func (t *categorizationRules) GetCategorizationRule(id *string) (*GetCategorizationRuleResult, error) {
+	return t.query.GetCategorizationRule(id)
+}
+func (t *categorizationRules) Create(args *CategorizationRuleArgs) (*CreateCategorizationRuleResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	res, err := t.service.Create(args.convert())
+	if err != nil {
+		return nil, err
+	}
+	return &CreateCategorizationRuleResult{RuleID: res.RuleID}, nil
+}
+func (t *CategorizationRuleArgs) valid() error {
+	err := core.NewError()
+	if t.RuleName == "" {
+		err.Append(application.RequiredName)
+	}
+	rule := &models.CategorizationRule{
+		NotesPattern: t.NotesPattern,
+		PayeePattern: t.PayeePattern,
+		MinAmount:    t.MinAmount,
+		MaxAmount:    t.MaxAmount,
+		ImportSource: t.ImportSource,
+		Category:     t.Category,
+		Tags:         t.Tags,
+		PayeeID:      t.PayeeID,
+	}
+	if !accountbook.HasRuleCondition(rule) {
+		err.Append(application.RequiredRuleCondition)
+	}
+	if !accountbook.HasRuleAction(rule) {
+		err.Append(application.RequiredRuleAction)
+	}
+	if t.MinAmount != nil && t.MaxAmount != nil && *t.MinAmount > *t.MaxAmount {
+		err.Append(application.InValidAmountRange)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+func (t *CategorizationRuleArgs) convert() *services.CategorizationRuleArgs {
+	return &services.CategorizationRuleArgs{
+		RuleName:     t.RuleName,
+		Priority:     t.Priority,
+		NotesPattern: t.NotesPattern,
+		PayeePattern: t.PayeePattern,
+		MinAmount:    t.MinAmount,
+		MaxAmount:    t.MaxAmount,
+		ImportSource: t.ImportSource,
+		Category:     t.Category,
+		Tags:         t.Tags,
+		PayeeID:      t.PayeeID,
+	}
+}
+func (t *categorizationRules) Update(id *string, args *CategorizationRuleArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	return t.service.Update(id, args.convert())
+}
+func (t *categorizationRules) Delete(id *string) error {
+	return t.service.Delete(id)
+}
+func (t *categorizationRules) Apply(args *ApplyCategorizationRulesArgs) (*ApplyCategorizationRulesResult, error) {
+	res, err := t.service.Apply(&services.ApplyCategorizationRulesArgs{
+		SelectedMonth: args.SelectedMonth,
+		Preview:       args.Preview,
+	})
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]CategorizationChangeResult, len(*res))
+	for i, change := range *res {
+		changes[i] = CategorizationChangeResult{
+			TransactionID:  change.TransactionID,
+			BeforeCategory: change.BeforeCategory,
+			Category:       change.Category,
+			PayeeID:        change.PayeeID,
+			Tags:           change.Tags,
+		}
+	}
+	return &ApplyCategorizationRulesResult{Changes: changes}, nil
+}
@@ -3,7 +3,9 @@ package usecases
 import (
 	"time"
 
+	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 )
 
 type (
@@ -15,8 +17,11 @@ type (
 	Dashboard interface {
 		GetDashboard(args *GetDashboardArgs) (*GetDashboardResult, error)
 		Approve(id *string) error
+		ApproveThrough(args *ApproveThroughArgs) (*ApproveThroughResult, error)
 		CancelApprove(id *string) error
-		AdjustBalance(args *AdjustBalanceArgs) error
+		CancelApproveCascade(args *CancelApproveCascadeArgs) (*CancelApproveCascadeResult, error)
+		AdjustBalance(args *AdjustBalanceArgs) (*AdjustBalanceResult, error)
+		GetAdjustments(id *string) (*GetAdjustmentsResult, error)
 	}
 	// GetDashboardArgs は引数です
 	GetDashboardArgs struct {
@@ -32,6 +37,7 @@ type (
 		PreviousBalance  *int
 		Plans            []PlanResult
 		Daily            []DailyResult
+		Categories       []CategoryResult
 		State            string
 		CanApprove       bool
 		CanCancelApprove bool
@@ -53,10 +59,58 @@ type (
 		Expense int
 		Balance int
 	}
+	// CategoryResult はカテゴリ毎の支出です
+	CategoryResult struct {
+		Category      int
+		Amount        int
+		Share         float64
+		Count         int
+		Uncategorized bool
+	}
+	// ApproveThroughArgs は引数です
+	ApproveThroughArgs struct {
+		Month  time.Time
+		Atomic bool
+	}
+	// ApproveThroughResult は結果です
+	ApproveThroughResult struct {
+		ApprovedMonths []time.Time
+		StoppedMonth   *time.Time
+		MissingPlanIDs []string
+	}
+	// CancelApproveCascadeArgs は引数です
+	CancelApproveCascadeArgs struct {
+		DashboardID string
+		Reclose     bool
+	}
+	// CancelApproveCascadeResult は結果です
+	CancelApproveCascadeResult struct {
+		ReopenedMonths []time.Time
+		ReclosedMonths []time.Time
+		StoppedMonth   *time.Time
+		MissingPlanIDs []string
+	}
 	// AdjustBalanceArgs は引数です
 	AdjustBalanceArgs struct {
 		DashboardID string
-		Balance     int
+		Amount      int
+		Reason      string
+	}
+	// AdjustBalanceResult は結果です
+	AdjustBalanceResult struct {
+		AdjustmentID string
+		Balance      int
+	}
+	// GetAdjustmentsResult は結果です
+	GetAdjustmentsResult struct {
+		Adjustments []AdjustmentResult
+	}
+	// AdjustmentResult は残高の調整です
+	AdjustmentResult struct {
+		AdjustmentID string
+		Amount       int
+		Reason       string
+		CreatedAt    time.Time
 	}
 )
 
@@ -80,12 +134,68 @@ func (t *dashboard) GetDashboard(args *GetDashboardArgs) (*GetDashboardResult, e
 func (t *dashboard) Approve(id *string) error {
 	return t.service.Approve(id)
 }
+func (t *dashboard) ApproveThrough(args *ApproveThroughArgs) (*ApproveThroughResult, error) {
+	res, err := t.service.ApproveThrough(&services.ApproveThroughArgs{
+		Month:  args.Month,
+		Atomic: args.Atomic,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ApproveThroughResult{
+		ApprovedMonths: res.ApprovedMonths,
+		StoppedMonth:   res.StoppedMonth,
+		MissingPlanIDs: res.MissingPlanIDs,
+	}, nil
+}
 func (t *dashboard) CancelApprove(id *string) error {
 	return t.service.CancelApprove(id)
 }
-func (t *dashboard) AdjustBalance(args *AdjustBalanceArgs) error {
-	return t.service.AdjustBalance(&services.AdjustBalanceArgs{
+func (t *dashboard) CancelApproveCascade(args *CancelApproveCascadeArgs) (*CancelApproveCascadeResult, error) {
+	res, err := t.service.CancelApproveCascade(&services.CancelApproveCascadeArgs{
 		DashboardID: args.DashboardID,
-		Balance:     args.Balance,
+		Reclose:     args.Reclose,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return &CancelApproveCascadeResult{
+		ReopenedMonths: res.ReopenedMonths,
+		ReclosedMonths: res.ReclosedMonths,
+		StoppedMonth:   res.StoppedMonth,
+		MissingPlanIDs: res.MissingPlanIDs,
+	}, nil
+}
+func (t *dashboard) AdjustBalance(args *AdjustBalanceArgs) (*AdjustBalanceResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	res, err := t.service.AdjustBalance(&services.AdjustBalanceArgs{
+		DashboardID: args.DashboardID,
+		Amount:      args.Amount,
+		Reason:      args.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AdjustBalanceResult{
+		AdjustmentID: res.AdjustmentID,
+		Balance:      res.Balance,
+	}, nil
+}
+func (t *AdjustBalanceArgs) valid() error {
+	err := core.NewError()
+	if t.Amount == 0 {
+		err.Append(application.RequiredAmount)
+	}
+	if t.Reason == "" {
+		err.Append(application.RequiredReason)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+func (t *dashboard) GetAdjustments(id *string) (*GetAdjustmentsResult, error) {
+	return t.query.GetAdjustments(id)
 }
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+)
+
+type (
+	digests struct {
+		query   DigestsQuery
+		service services.Digests
+	}
+	// Digests is DigestsUseCases
+	Digests interface {
+		GetSubscriberIDs() ([]string, error)
+		Send() (*SendDigestsResult, error)
+	}
+	// SendDigestsResult は結果です
+	SendDigestsResult struct {
+		Weekly   bool
+		MonthEnd bool
+	}
+)
+
+// NewDigests is create instance
+func NewDigests(query DigestsQuery, service services.Digests) Digests {
+	return &digests{query, service}
+}
+func (t *digests) GetSubscriberIDs() ([]string, error) {
+	return t.query.GetSubscriberIDs()
+}
+func (t *digests) Send() (*SendDigestsResult, error) {
+	res, err := t.service.Send()
+	if err != nil {
+		return nil, err
+	}
+	return &SendDigestsResult{
+		Weekly:   res.Weekly,
+		MonthEnd: res.MonthEnd,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+const (
+	// DefaultForecastMonths は予測する月数の既定値です
+	DefaultForecastMonths = 6
+	// MaxForecastMonths は予測できる月数の上限です
+	MaxForecastMonths = 60
+)
+
+type (
+	forecast struct {
+		query ForecastQuery
+	}
+	// Forecast is ForecastUseCases
+	Forecast interface {
+		GetForecast(args *GetForecastArgs) (*GetForecastResult, error)
+	}
+	// GetForecastArgs は引数です
+	GetForecastArgs struct {
+		Months           int
+		IncludeUnplanned bool
+	}
+	// GetForecastResult は結果です
+	GetForecastResult struct {
+		StartBalance int
+		Months       []ForecastMonthResult
+	}
+	// ForecastMonthResult は月毎の予測です
+	ForecastMonthResult struct {
+		Month            time.Time
+		Income           int
+		Expense          int
+		UnplannedIncome  int
+		UnplannedExpense int
+		Balance          int
+	}
+)
+
+// NewForecast is create instance
+func NewForecast(query ForecastQuery) Forecast {
+	return &forecast{query}
+}
+func (t *forecast) GetForecast(args *GetForecastArgs) (*GetForecastResult, error) {
+	if args.Months == 0 {
+		args.Months = DefaultForecastMonths
+	}
+	if args.Months < 0 || args.Months > MaxForecastMonths {
+		return nil, core.NewError(application.InValidForecastMonths)
+	}
+	return t.query.GetForecast(args)
+}
@@ -51,10 +51,35 @@ type (
 	TransactionsQuery interface {
 		GetTransactions(args *GetTransactionsArgs) (*GetTransactionsResult, error)
 		GetTransaction(id *string) (*GetTransactionResult, error)
+		GetDuplicates(args *GetDuplicatesArgs) (*GetDuplicatesResult, error)
+	}
+	// AttachmentsQuery は添付ファイルのクエリです
+	AttachmentsQuery interface {
+		GetAttachments(transactionID *string) (*GetAttachmentsResult, error)
+		GetAttachment(args *GetAttachmentArgs) (*GetAttachmentResult, error)
+	}
+	// SettingsQuery はユーザー設定のクエリです
+	SettingsQuery interface {
+		GetSettings() (*GetSettingsResult, error)
+		GetDigestSettings() (*GetDigestSettingsResult, error)
+	}
+	// DigestsQuery はまとめメールのクエリです
+	DigestsQuery interface {
+		GetSubscriberIDs() ([]string, error)
+	}
+	// PayeesQuery は支払先のクエリです
+	PayeesQuery interface {
+		GetPayees() (*GetPayeesResult, error)
+		GetPayee(id *string) (*GetPayeeResult, error)
+	}
+	// CategorizationRulesQuery は自動分類ルールのクエリです
+	CategorizationRulesQuery interface {
+		GetCategorizationRules() (*GetCategorizationRulesResult, error)
+		GetCategorizationRule(id *string) (*GetCategorizationRuleResult, error)
 	}
 	// PlansQuery は計画のクエリです
 	PlansQuery interface {
-		GetPlans() (*GetPlansResult, error)
+		GetPlans(args *GetPlansArgs) (*GetPlansResult, error)
 		GetPlan(id *string) (*GetPlanResult, error)
 	}
 	// NotificationRulesQuery は通知設定のクエリです
@@ -62,9 +87,34 @@ type (
 		GetNotificationRules() *GetNotificationRulesResult
 		GetNotificationRule(id *string) (*GetNotificationRuleResult, core.Error)
 	}
+	// NotificationsQuery はアプリ内の通知のクエリです
+	NotificationsQuery interface {
+		GetNotifications(args *GetNotificationsArgs) (*GetNotificationsResult, core.Error)
+		GetUnreadCount() *GetUnreadCountResult
+	}
+	// NotificationChannelsQuery は通知の配信先の設定のクエリです
+	NotificationChannelsQuery interface {
+		GetNotificationChannels() *GetNotificationChannelsResult
+	}
 	// DashboardQuery はダッシュボードのクエリです
 	DashboardQuery interface {
 		GetSummary(args *GetDashboardArgs) (*GetDashboardResult, error)
+		GetAdjustments(id *string) (*GetAdjustmentsResult, error)
+	}
+	// BudgetsQuery は予算のクエリです
+	BudgetsQuery interface {
+		GetBudgets() (*GetBudgetsResult, error)
+		GetBudget(id *string) (*GetBudgetResult, error)
+		GetBudgetReport(args *GetBudgetReportArgs) (*GetBudgetReportResult, error)
+	}
+	// ReportsQuery はレポートのクエリです
+	ReportsQuery interface {
+		GetMonthlyReport(args *GetMonthlyReportArgs) (*GetMonthlyReportResult, error)
+		GetNetWorth(args *GetNetWorthArgs) (*GetNetWorthResult, error)
+	}
+	// ForecastQuery は収支予測のクエリです
+	ForecastQuery interface {
+		GetForecast(args *GetForecastArgs) (*GetForecastResult, error)
 	}
 	// ActualQuery は実績のクエリです
 	ActualQuery interface {
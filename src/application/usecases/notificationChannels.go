@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	notificationChannels struct {
+		query      NotificationChannelsQuery
+		repository notifications.ChannelSettingsRepository
+	}
+	// NotificationChannels is NotificationChannelsUseCases
+	NotificationChannels interface {
+		GetNotificationChannels() *GetNotificationChannelsResult
+		Update(args *NotificationChannelsArgs) core.Error
+	}
+	// GetNotificationChannelsResult は結果です
+	// シークレットは返さず、設定済みかどうかのみを返します
+	GetNotificationChannelsResult struct {
+		WebhookURL       string
+		HasWebhookSecret bool
+	}
+	// NotificationChannelsArgs は引数です
+	NotificationChannelsArgs struct {
+		WebhookURL string
+		// WebhookSecret が空の場合は設定済みのシークレットを引き継ぎます
+		WebhookSecret string
+	}
+)
+
+// NewNotificationChannels is create instance
+func NewNotificationChannels(
+	query NotificationChannelsQuery,
+	repository notifications.ChannelSettingsRepository,
+) NotificationChannels {
+	return &notificationChannels{query, repository}
+}
+func (t *notificationChannels) GetNotificationChannels() *GetNotificationChannelsResult {
+	return t.query.GetNotificationChannels()
+}
+func (t *notificationChannels) Update(args *NotificationChannelsArgs) core.Error {
+	settings := t.repository.Get()
+	settings.SetWebhookURL(args.WebhookURL)
+	if args.WebhookURL == "" {
+		settings.SetWebhookSecret("")
+	} else if args.WebhookSecret != "" {
+		settings.SetWebhookSecret(args.WebhookSecret)
+	}
+	if err := settings.Valid(); err != nil {
+		return err
+	}
+	t.repository.Save(settings)
+	return nil
+}
@@ -1,6 +1,9 @@
 package usecases
 
 import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
 )
@@ -27,11 +30,29 @@ type (
 		NotificationRuleID string
 		Metrics            string
 		Threshold          int
+		ThresholdUnit      string
+		Operator           string
+		Period             string
+		Category           *int
+		Channels           []string
+		Rearm              bool
+		CooldownHours      int
+		LastFiredAt        *time.Time
+		LastValue          *int
 	}
 	// NotificationRuleArgs は引数です
+	// 比較方法と期間を指定しない場合はメトリクスの既定の比較方法と当月とします
 	NotificationRuleArgs struct {
-		Metrics   string
-		Threshold int
+		Metrics       string
+		Threshold     int
+		ThresholdUnit string
+		Operator      string
+		Period        string
+		Category      *int
+		Channels      []string
+		// Rearm を指定しない場合は条件を満たさなくなったら再び通知できるようにします
+		Rearm         *bool
+		CooldownHours int
 	}
 	// CreateNotificationRuleResult は結果です
 	CreateNotificationRuleResult struct {
@@ -57,26 +78,94 @@ func (t *notificationRules) GetNotificationRule(id *string) (*GetNotificationRul
 	return info, nil
 }
 func (t *notificationRules) Create(args *NotificationRuleArgs) (*CreateNotificationRuleResult, core.Error) {
-	metrics, err := notifications.NewMetrics(args.Metrics)
+	notificationRule, err := args.build(nil)
 	if err != nil {
 		return nil, err
 	}
-	threshold := notifications.NewThreshold(args.Threshold)
-	res := t.repository.New(metrics, threshold)
+	res := t.repository.New(notificationRule)
 	return &CreateNotificationRuleResult{
 		NotificationRuleID: *res.GetID(),
 	}, nil
 }
+
+// build は引数から通知ルールを生成し、全ての項目を検証します
+func (t *NotificationRuleArgs) build(id notifications.NotificationRuleID) (notifications.NotificationRule, core.Error) {
+	err := core.NewError()
+	var metrics notifications.Metrics
+	if t.Metrics == "" {
+		err.Append(application.RequiredMetrics)
+	} else {
+		var e core.Error
+		if metrics, e = notifications.NewMetrics(t.Metrics); e != nil {
+			err.Concat(e)
+		}
+	}
+	threshold, e := notifications.NewThreshold(t.Threshold, t.ThresholdUnit)
+	if e != nil {
+		err.Concat(e)
+	}
+	channels, e := notifications.NewChannels(t.Channels)
+	if e != nil {
+		err.Concat(e)
+	}
+	var operator notifications.Operator
+	if t.Operator != "" {
+		if operator, e = notifications.NewOperator(t.Operator); e != nil {
+			err.Concat(e)
+		}
+	}
+	cooldown, e := notifications.NewCooldown(t.CooldownHours)
+	if e != nil {
+		err.Concat(e)
+	}
+	var period notifications.Period
+	if t.Period != "" {
+		if period, e = notifications.NewPeriod(t.Period); e != nil {
+			err.Concat(e)
+		}
+	}
+	if err.HasError() {
+		return nil, err
+	}
+	notificationRule := notifications.NewNotificationRule(id, metrics, threshold, channels)
+	if operator != nil {
+		notificationRule.SetOperator(operator)
+	}
+	if period != nil {
+		notificationRule.SetPeriod(period)
+	}
+	notificationRule.SetCategory(t.Category)
+	notificationRule.SetCooldown(cooldown)
+	if t.Rearm != nil {
+		notificationRule.SetRearm(*t.Rearm)
+	}
+	if err := notificationRule.Valid(); err != nil {
+		return nil, err
+	}
+	return notificationRule, nil
+}
+
+// Update は通知ルールの条件を置き換えます
+// 条件が変わるため前回の評価の状態は引き継ぎませんが、通知の間隔を守るため最後に通知した日時は引き継ぎます
+// 同時に行われる評価が記録した通知日時や再配信を待つ通知を失わないよう、読み込みから書き込みまでを排他的に行います
 func (t *notificationRules) Update(id *string, args *NotificationRuleArgs) core.Error {
-	notificationRule, err := t.repository.GetByID(notifications.NotificationRuleID(id))
-	metrics, err := notifications.NewMetrics(args.Metrics)
+	notificationRule, err := args.build(notifications.NotificationRuleID(id))
 	if err != nil {
 		return err
 	}
-	notificationRule.SetMetrics(metrics)
-	notificationRule.SetThreshold(notifications.NewThreshold(args.Threshold))
-	t.repository.Save(notificationRule)
-	return nil
+	return t.repository.Modify(notifications.NotificationRuleID(id), func(current notifications.NotificationRule) bool {
+		current.SetMetrics(notificationRule.GetMetrics())
+		current.SetThreshold(notificationRule.GetThreshold())
+		current.SetOperator(notificationRule.GetOperator())
+		current.SetPeriod(notificationRule.GetPeriod())
+		current.SetCategory(notificationRule.GetCategory())
+		current.SetChannels(notificationRule.GetChannels())
+		current.SetRearm(notificationRule.GetRearm())
+		current.SetCooldown(notificationRule.GetCooldown())
+		current.SetExceededPeriod(nil)
+		current.SetLastObservation(nil)
+		return true
+	})
 }
 func (t *notificationRules) Delete(id *string) core.Error {
 	return t.repository.Delete(notifications.NotificationRuleID(id))
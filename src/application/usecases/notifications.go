@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/domains/notifications"
+)
+
+type (
+	inbox struct {
+		query      NotificationsQuery
+		repository notifications.InboxRepository
+	}
+	// Notifications is NotificationsUseCases
+	Notifications interface {
+		GetNotifications(args *GetNotificationsArgs) (*GetNotificationsResult, core.Error)
+		GetUnreadCount() *GetUnreadCountResult
+		Read(id *string) core.Error
+		ReadAll() *ReadAllNotificationsResult
+	}
+	// GetNotificationsArgs は引数です
+	GetNotificationsArgs struct {
+		Limit      int
+		Cursor     *string
+		UnreadOnly bool
+	}
+	// GetNotificationsResult は結果です
+	GetNotificationsResult struct {
+		Notifications []GetNotificationResult
+		NextCursor    *string
+	}
+	// GetNotificationResult は結果です
+	GetNotificationResult struct {
+		NotificationID     string
+		Title              string
+		Body               string
+		Severity           string
+		NotificationRuleID string
+		CreatedAt          time.Time
+		ReadAt             *time.Time
+	}
+	// GetUnreadCountResult は結果です
+	GetUnreadCountResult struct {
+		Count int
+	}
+	// ReadAllNotificationsResult は結果です
+	ReadAllNotificationsResult struct {
+		Count int
+	}
+)
+
+const (
+	// DefaultNotificationsLimit は通知を取得する件数の既定値です
+	DefaultNotificationsLimit = 20
+	// MaxNotificationsLimit は通知を取得する件数の上限です
+	MaxNotificationsLimit = 100
+)
+
+// NewNotifications is create instance
+func NewNotifications(
+	query NotificationsQuery,
+	repository notifications.InboxRepository,
+) Notifications {
+	return &inbox{query, repository}
+}
+func (t *inbox) GetNotifications(args *GetNotificationsArgs) (*GetNotificationsResult, core.Error) {
+	if args.Limit == 0 {
+		args.Limit = DefaultNotificationsLimit
+	}
+	if args.Limit < 0 || args.Limit > MaxNotificationsLimit {
+		return nil, core.NewError(application.InValidLimit)
+	}
+	return t.query.GetNotifications(args)
+}
+func (t *inbox) GetUnreadCount() *GetUnreadCountResult {
+	return t.query.GetUnreadCount()
+}
+func (t *inbox) Read(id *string) core.Error {
+	return t.repository.Read(*id)
+}
+func (t *inbox) ReadAll() *ReadAllNotificationsResult {
+	return &ReadAllNotificationsResult{Count: t.repository.ReadAll()}
+}
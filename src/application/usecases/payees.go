@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	payees struct {
+		query   PayeesQuery
+		service services.Payees
+	}
+	// Payees is PayeesUseCases
+	Payees interface {
+		GetPayees() (*GetPayeesResult, error)
+		GetPayee(id *string) (*GetPayeeResult, error)
+		Create(args *PayeeArgs) (*CreatePayeeResult, error)
+		Update(id *string, args *PayeeArgs) error
+		Delete(id *string) error
+	}
+	// GetPayeesResult は結果です
+	GetPayeesResult struct {
+		Payees []GetPayeeResult
+	}
+	// GetPayeeResult は結果です
+	GetPayeeResult struct {
+		PayeeID   string
+		PayeeName string
+	}
+	// PayeeArgs は引数です
+	PayeeArgs struct {
+		PayeeName string
+	}
+	// CreatePayeeResult は結果です
+	CreatePayeeResult struct {
+		PayeeID string
+	}
+)
+
+// NewPayees is create instance
+func NewPayees(
+	query PayeesQuery,
+	service services.Payees,
+) Payees {
+	return &payees{
+		query,
+		service,
+	}
+}
+func (t *payees) GetPayees() (*GetPayeesResult, error) {
+	return t.query.GetPayees()
+}
+func (t *payees) GetPayee(id *string) (*GetPayeeResult, error) {
+	return t.query.GetPayee(id)
+}
+func (t *payees) Create(args *PayeeArgs) (*CreatePayeeResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	res, err := t.service.Create(args.convert())
+	if err != nil {
+		return nil, err
+	}
+	return &CreatePayeeResult{PayeeID: res.PayeeID}, nil
+}
+func (t *PayeeArgs) valid() error {
+	if t.PayeeName == "" {
+		return core.NewError(application.RequiredName)
+	}
+	return nil
+}
+func (t *PayeeArgs) convert() *services.PayeeArgs {
+	return &services.PayeeArgs{PayeeName: t.PayeeName}
+}
+func (t *payees) Update(id *string, args *PayeeArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	return t.service.Update(id, args.convert())
+}
+func (t *payees) Delete(id *string) error {
+	return t.service.Delete(id)
+}
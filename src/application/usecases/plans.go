@@ -6,6 +6,8 @@ import (
 	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/application/services"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
 )
 
 type (
@@ -15,11 +17,17 @@ type (
 	}
 	// Plans is PlansUseCases
 	Plans interface {
-		GetPlans() (*GetPlansResult, error)
+		GetPlans(args *GetPlansArgs) (*GetPlansResult, error)
 		GetPlan(id *string) (*GetPlanResult, error)
 		Create(args *PlanArgs) (*CreatePlanResult, error)
 		Update(id *string, args *PlanArgs) error
 		Remove(id *string) error
+		Restore(id *string) error
+		MigrateRecurrences() (*MigrateRecurrencesResult, error)
+	}
+	// GetPlansArgs は引数です
+	GetPlansArgs struct {
+		IncludeDeleted bool
 	}
 	// GetPlansResult は結果です
 	GetPlansResult struct {
@@ -32,22 +40,49 @@ type (
 		IsIncome   bool
 		PlanAmount int
 		Interval   int
+		Recurrence RecurrenceArgs
 		Start      *time.Time
 		End        *time.Time
+		Revisions  []PlanRevisionResult
+		IsDeleted  bool
+		DeletedAt  *time.Time
+	}
+	// PlanRevisionResult は計画の改定の結果です
+	PlanRevisionResult struct {
+		EffectiveFrom time.Time
+		PlanName      string
+		PlanAmount    int
+		IsIncome      bool
+		CreatedAt     time.Time
 	}
 	// PlanArgs は引数です
 	PlanArgs struct {
-		PlanName   string
-		IsIncome   bool
-		PlanAmount int
+		PlanName      string
+		IsIncome      bool
+		PlanAmount    int
+		Interval      int
+		Recurrence    *RecurrenceArgs
+		Start         *time.Time
+		End           *time.Time
+		EffectiveFrom *time.Time
+	}
+	// RecurrenceArgs は繰り返し規則の引数です
+	RecurrenceArgs struct {
+		Frequency  string
 		Interval   int
-		Start      *time.Time
-		End        *time.Time
+		ByMonth    []int
+		ByMonthDay []int
+		Count      *int
+		Until      *time.Time
 	}
 	// CreatePlanResult は結果です
 	CreatePlanResult struct {
 		PlanID string
 	}
+	// MigrateRecurrencesResult は結果です
+	MigrateRecurrencesResult struct {
+		Count int
+	}
 )
 
 // NewPlans is create instance
@@ -60,8 +95,8 @@ func NewPlans(
 		service,
 	}
 }
-func (t *plans) GetPlans() (*GetPlansResult, error) {
-	info, err := t.query.GetPlans()
+func (t *plans) GetPlans(args *GetPlansArgs) (*GetPlansResult, error) {
+	info, err := t.query.GetPlans(args)
 	if err != nil {
 		return nil, err
 	}
@@ -91,27 +126,71 @@ func (t *PlanArgs) valid() error {
 	if t.PlanName == "" {
 		err.Append(application.RequiredPlanName)
 	}
-	if t.Interval <= 0 {
-		err.Append(application.MoreThanZeroInterval)
+	if t.Recurrence == nil {
+		if t.Interval <= 0 {
+			err.Append(application.MoreThanZeroInterval)
+		}
+	} else {
+		t.Recurrence.valid(err)
 	}
 	if t.Start != nil && t.End != nil && t.Start.After(*t.End) {
 		err.Append(application.InValidDateRange)
 	}
+	if t.Start != nil && t.Recurrence != nil && t.Recurrence.Until != nil && t.Start.After(*t.Recurrence.Until) {
+		err.Append(application.InValidDateRange)
+	}
 	if err.HasError() {
 		return err
 	}
 	return nil
 }
-func (t *PlanArgs) convert() *services.PlanArgs {
-	return &services.PlanArgs{
-		PlanName:   t.PlanName,
-		IsIncome:   t.IsIncome,
-		PlanAmount: t.PlanAmount,
-		Interval:   t.Interval,
-		Start:      t.Start,
-		End:        t.End,
+func (t *RecurrenceArgs) valid(err core.Error) {
+	if !accountbook.IsValidFrequency(t.Frequency) {
+		err.Append(application.InValidFrequency)
+	}
+	if t.Interval <= 0 {
+		err.Append(application.MoreThanZeroInterval)
+	}
+	invalid := t.Count != nil && *t.Count <= 0
+	for _, month := range t.ByMonth {
+		if month < 1 || month > 12 {
+			invalid = true
+		}
+	}
+	for _, day := range t.ByMonthDay {
+		if day == 0 || day < -31 || day > 31 {
+			invalid = true
+		}
+	}
+	if t.Frequency == accountbook.FrequencyWeekly && len(t.ByMonthDay) > 0 {
+		invalid = true
+	}
+	if invalid {
+		err.Append(application.InValidRecurrence)
 	}
 }
+func (t *PlanArgs) convert() *services.PlanArgs {
+	args := &services.PlanArgs{
+		PlanName:      t.PlanName,
+		IsIncome:      t.IsIncome,
+		PlanAmount:    t.PlanAmount,
+		Interval:      t.Interval,
+		Start:         t.Start,
+		End:           t.End,
+		EffectiveFrom: t.EffectiveFrom,
+	}
+	if t.Recurrence != nil {
+		args.Recurrence = &models.Recurrence{
+			Frequency:  t.Recurrence.Frequency,
+			Interval:   t.Recurrence.Interval,
+			ByMonth:    t.Recurrence.ByMonth,
+			ByMonthDay: t.Recurrence.ByMonthDay,
+			Count:      t.Recurrence.Count,
+			Until:      t.Recurrence.Until,
+		}
+	}
+	return args
+}
 func (t *plans) Update(id *string, args *PlanArgs) error {
 	if err := args.valid(); err != nil {
 		return err
@@ -121,3 +200,13 @@ func (t *plans) Update(id *string, args *PlanArgs) error {
 func (t *plans) Remove(id *string) error {
 	return t.service.Remove(id)
 }
+func (t *plans) Restore(id *string) error {
+	return t.service.Restore(id)
+}
+func (t *plans) MigrateRecurrences() (*MigrateRecurrencesResult, error) {
+	res, err := t.service.MigrateRecurrences()
+	if err != nil {
+		return nil, err
+	}
+	return &MigrateRecurrencesResult{Count: res.Count}, nil
+}
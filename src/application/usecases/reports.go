@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+// MaxReportMonths はレポートで取得できる月数の上限です
+const MaxReportMonths = 120
+
+const (
+	// GranularityMonthly は月単位の集計です
+	GranularityMonthly = "monthly"
+	// GranularityDaily は日単位の集計です
+	GranularityDaily = "daily"
+)
+
+type (
+	reports struct {
+		query ReportsQuery
+	}
+	// Reports is ReportsUseCases
+	Reports interface {
+		GetMonthlyReport(args *GetMonthlyReportArgs) (*GetMonthlyReportResult, error)
+		GetYearlyReport(args *GetYearlyReportArgs) (*GetYearlyReportResult, error)
+		GetNetWorth(args *GetNetWorthArgs) (*GetNetWorthResult, error)
+	}
+	// GetMonthlyReportArgs は引数です
+	GetMonthlyReportArgs struct {
+		From time.Time
+		To   time.Time
+	}
+	// GetMonthlyReportResult は結果です
+	GetMonthlyReportResult struct {
+		Months []MonthlyReportResult
+	}
+	// MonthlyReportResult は月毎の収支です
+	MonthlyReportResult struct {
+		Month             time.Time
+		Income            int
+		Expense           int
+		Balance           int
+		CumulativeBalance int
+		State             string
+	}
+	// GetYearlyReportArgs は引数です
+	GetYearlyReportArgs struct {
+		From int
+		To   int
+	}
+	// GetYearlyReportResult は結果です
+	GetYearlyReportResult struct {
+		Years []YearlyReportResult
+	}
+	// YearlyReportResult は年毎の収支です
+	YearlyReportResult struct {
+		Year           int
+		Income         int
+		Expense        int
+		Balance        int
+		AverageIncome  int
+		AverageExpense int
+		AverageBalance int
+		BestMonth      *MonthlyReportResult
+		WorstMonth     *MonthlyReportResult
+		Months         []MonthlyReportResult
+	}
+	// GetNetWorthArgs は引数です
+	GetNetWorthArgs struct {
+		Granularity string
+	}
+	// GetNetWorthResult は結果です
+	GetNetWorthResult struct {
+		Points []NetWorthPoint
+	}
+	// NetWorthPoint は残高の推移の一点です
+	// 日単位の場合も月末の点は締めた時の残高で、実績と残高の調整は月末の点にのみ反映されます
+	NetWorthPoint struct {
+		Date      time.Time
+		Balance   int
+		Estimated bool
+	}
+)
+
+// NewReports is create instance
+func NewReports(query ReportsQuery) Reports {
+	return &reports{query}
+}
+func (t *reports) GetMonthlyReport(args *GetMonthlyReportArgs) (*GetMonthlyReportResult, error) {
+	if err := args.valid(); err != nil {
+		return nil, err
+	}
+	return t.query.GetMonthlyReport(args)
+}
+func (t *GetMonthlyReportArgs) valid() error {
+	if t.From.After(t.To) {
+		return core.NewError(application.InValidDateRange)
+	}
+	months := (t.To.Year()-t.From.Year())*12 + int(t.To.Month()) - int(t.From.Month()) + 1
+	if months > MaxReportMonths {
+		return core.NewError(application.TooLongPeriod)
+	}
+	return nil
+}
+func (t *reports) GetYearlyReport(args *GetYearlyReportArgs) (*GetYearlyReportResult, error) {
+	if args.From > args.To {
+		return nil, core.NewError(application.InValidDateRange)
+	}
+	monthly, err := t.GetMonthlyReport(&GetMonthlyReportArgs{
+		// 月の開始日が 1 日でなくても各月がその年に属するよう、開始日より後の日を指定します
+		From: time.Date(args.From, time.January, core.MaxMonthStartDay, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(args.To, time.December, core.MaxMonthStartDay, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return nil, err
+	}
+	years := make([]YearlyReportResult, 0, args.To-args.From+1)
+	for _, month := range monthly.Months {
+		if len(years) == 0 || years[len(years)-1].Year != month.Month.Year() {
+			years = append(years, YearlyReportResult{Year: month.Month.Year()})
+		}
+		years[len(years)-1].add(month)
+	}
+	for i := range years {
+		years[i].average()
+	}
+	return &GetYearlyReportResult{Years: years}, nil
+}
+func (t *YearlyReportResult) add(month MonthlyReportResult) {
+	t.Income += month.Income
+	t.Expense += month.Expense
+	t.Balance += month.Balance
+	t.Months = append(t.Months, month)
+	if t.BestMonth == nil || month.Balance > t.BestMonth.Balance {
+		best := month
+		t.BestMonth = &best
+	}
+	if t.WorstMonth == nil || month.Balance < t.WorstMonth.Balance {
+		worst := month
+		t.WorstMonth = &worst
+	}
+}
+func (t *YearlyReportResult) average() {
+	count := len(t.Months)
+	if count == 0 {
+		return
+	}
+	t.AverageIncome = t.Income / count
+	t.AverageExpense = t.Expense / count
+	t.AverageBalance = t.Balance / count
+}
+func (t *reports) GetNetWorth(args *GetNetWorthArgs) (*GetNetWorthResult, error) {
+	if args.Granularity == "" {
+		args.Granularity = GranularityMonthly
+	}
+	if args.Granularity != GranularityMonthly && args.Granularity != GranularityDaily {
+		return nil, core.NewError(application.InValidGranularity)
+	}
+	return t.query.GetNetWorth(args)
+}
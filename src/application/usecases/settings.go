@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/application"
+	"github.com/wakuwaku3/account-book.api/src/application/services"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	settings struct {
+		query   SettingsQuery
+		service services.Settings
+	}
+	// Settings is SettingsUseCases
+	Settings interface {
+		GetSettings() (*GetSettingsResult, error)
+		UpdateSettings(args *SettingsArgs) error
+		GetDigestSettings() (*GetDigestSettingsResult, error)
+		UpdateDigestSettings(args *DigestSettingsArgs) error
+	}
+	// GetSettingsResult は結果です
+	GetSettingsResult struct {
+		UserName      string
+		Email         string
+		Culture       string
+		MonthStartDay int
+		TimeZone      string
+		// CurrentMonthDay は月の開始日から求めた当月の開始日です
+		CurrentMonthDay time.Time
+	}
+	// SettingsArgs は引数です
+	SettingsArgs struct {
+		MonthStartDay int
+		TimeZone      string
+	}
+	// GetDigestSettingsResult は結果です
+	GetDigestSettingsResult struct {
+		WeeklyDigest   bool
+		DigestWeekday  int
+		MonthEndDigest bool
+	}
+	// DigestSettingsArgs は引数です
+	DigestSettingsArgs struct {
+		WeeklyDigest   bool
+		DigestWeekday  int
+		MonthEndDigest bool
+	}
+)
+
+// NewSettings is create instance
+func NewSettings(
+	query SettingsQuery,
+	service services.Settings,
+) Settings {
+	return &settings{
+		query,
+		service,
+	}
+}
+func (t *settings) GetSettings() (*GetSettingsResult, error) {
+	return t.query.GetSettings()
+}
+func (t *settings) UpdateSettings(args *SettingsArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	// 区切りが変わらない場合は値だけを保存するため、変更の有無に関わらずそれぞれ更新します
+	if err := t.service.UpdateMonthStartDay(args.MonthStartDay); err != nil {
+		return err
+	}
+	return t.service.UpdateTimeZone(args.TimeZone)
+}
+func (t *SettingsArgs) valid() error {
+	err := core.NewError()
+	if t.MonthStartDay < 1 || t.MonthStartDay > core.MaxMonthStartDay {
+		err.Append(application.InValidMonthStartDay)
+	}
+	if t.TimeZone != "" && !core.IsValidLocation(t.TimeZone) {
+		err.Append(application.InValidTimeZone)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+func (t *settings) GetDigestSettings() (*GetDigestSettingsResult, error) {
+	return t.query.GetDigestSettings()
+}
+func (t *settings) UpdateDigestSettings(args *DigestSettingsArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	return t.service.UpdateDigest(&services.DigestSettingsArgs{
+		WeeklyDigest:   args.WeeklyDigest,
+		DigestWeekday:  args.DigestWeekday,
+		MonthEndDigest: args.MonthEndDigest,
+	})
+}
+func (t *DigestSettingsArgs) valid() error {
+	if t.DigestWeekday < int(time.Sunday) || t.DigestWeekday > int(time.Saturday) {
+		return core.NewError(application.InValidWeekday)
+	}
+	return nil
+}
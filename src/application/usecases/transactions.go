@@ -6,6 +6,7 @@ import (
 	"github.com/wakuwaku3/account-book.api/src/application"
 	"github.com/wakuwaku3/account-book.api/src/application/services"
 	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	accountbook "github.com/wakuwaku3/account-book.api/src/enterprise/domains/accountBook"
 )
 
 type (
@@ -20,6 +21,9 @@ type (
 		Create(args *TransactionArgs) (*CreateTransactionResult, error)
 		Update(id *string, args *TransactionArgs) error
 		Delete(id *string) error
+		Batch(args *BatchTransactionsArgs) (*BatchTransactionsResult, error)
+		GetDuplicates(args *GetDuplicatesArgs) (*GetDuplicatesResult, error)
+		Merge(args *MergeTransactionsArgs) error
 	}
 	// GetTransactionsArgs は引数です
 	GetTransactionsArgs struct {
@@ -31,25 +35,78 @@ type (
 	}
 	// GetTransactionResult は結果です
 	GetTransactionResult struct {
-		TransactionID string
-		Amount        int
-		Category      int
-		Date          time.Time
-		Notes         *string
-		Editable      bool
+		TransactionID   string
+		Amount          int
+		Category        int
+		Uncategorized   bool
+		Date            time.Time
+		Notes           *string
+		ImportReference *string
+		ImportSource    *string
+		PayeeID         *string
+		Tags            []string
+		Editable        bool
 	}
 	// TransactionArgs は引数です
+	// Category は登録時のみ省略でき、省略した場合は未分類として自動分類のルールでカテゴリを設定します
 	TransactionArgs struct {
-		Amount   *int
-		Category *int
-		Notes    *string
+		Amount          *int
+		Category        *int
+		Notes           *string
+		ImportReference *string
+		ImportSource    *string
+		PayeeID         *string
+		Tags            []string
 	}
 	// CreateTransactionResult は結果です
 	CreateTransactionResult struct {
-		TransactionID string
+		TransactionID           string
+		PossibleDuplicate       bool
+		DuplicateTransactionIDs []string
+	}
+	// GetDuplicatesArgs は引数です
+	GetDuplicatesArgs struct {
+		SelectedMonth time.Time
+		Days          int
+	}
+	// GetDuplicatesResult は結果です
+	GetDuplicatesResult struct {
+		Groups []DuplicateGroupResult
+	}
+	// DuplicateGroupResult は重複の可能性がある取引のまとまりです
+	DuplicateGroupResult struct {
+		Transactions []GetTransactionResult
+	}
+	// MergeTransactionsArgs は引数です
+	MergeTransactionsArgs struct {
+		TransactionID       string
+		MergeTransactionIDs []string
+	}
+	// BatchTransactionsArgs は引数です
+	BatchTransactionsArgs struct {
+		Operations []BatchTransactionArgs
+	}
+	// BatchTransactionArgs は一括操作の1件分の引数です
+	BatchTransactionArgs struct {
+		Operation     string
+		TransactionID *string
+		TransactionArgs
+	}
+	// BatchTransactionsResult は結果です
+	BatchTransactionsResult struct {
+		Results []BatchTransactionResult
+	}
+	// BatchTransactionResult は一括操作の1件分の結果です
+	BatchTransactionResult struct {
+		TransactionID *string
+		Error         core.Error
 	}
 )
 
+// maxBatchOperations は一括操作で受け付ける上限件数です
+// 1 回の書き込みで全件を反映するため、リポジトリの上限と同じとします
+const maxBatchOperations = application.MaxTransactionsBatchSize
+
 // NewTransactions is create instance
 func NewTransactions(
 	query TransactionsQuery,
@@ -75,7 +132,7 @@ func (t *transactions) GetTransaction(id *string) (*GetTransactionResult, error)
 	return info, nil
 }
 func (t *transactions) Create(args *TransactionArgs) (*CreateTransactionResult, error) {
-	if err := args.valid(); err != nil {
+	if err := args.validCreate(); err != nil {
 		return nil, err
 	}
 	res, err := t.service.Create(args.convert())
@@ -83,7 +140,9 @@ func (t *transactions) Create(args *TransactionArgs) (*CreateTransactionResult,
 		return nil, err
 	}
 	return &CreateTransactionResult{
-		TransactionID: res.TransactionID,
+		TransactionID:           res.TransactionID,
+		PossibleDuplicate:       len(res.DuplicateTransactionIDs) > 0,
+		DuplicateTransactionIDs: res.DuplicateTransactionIDs,
 	}, nil
 }
 func (t *TransactionArgs) valid() error {
@@ -99,11 +158,23 @@ func (t *TransactionArgs) valid() error {
 	}
 	return nil
 }
+
+// validCreate は登録時の引数を検証します。登録時はカテゴリを省略できます
+func (t *TransactionArgs) validCreate() error {
+	if t.Amount == nil {
+		return core.NewError(application.RequiredAmount)
+	}
+	return nil
+}
 func (t *TransactionArgs) convert() *services.TransactionArgs {
 	return &services.TransactionArgs{
-		Amount:   *t.Amount,
-		Category: *t.Category,
-		Notes:    t.Notes,
+		Amount:          *t.Amount,
+		Category:        t.Category,
+		Notes:           t.Notes,
+		ImportReference: t.ImportReference,
+		ImportSource:    t.ImportSource,
+		PayeeID:         t.PayeeID,
+		Tags:            t.Tags,
 	}
 }
 func (t *transactions) Update(id *string, args *TransactionArgs) error {
@@ -115,3 +186,122 @@ func (t *transactions) Update(id *string, args *TransactionArgs) error {
 func (t *transactions) Delete(id *string) error {
 	return t.service.Delete(id)
 }
+func (t *transactions) Batch(args *BatchTransactionsArgs) (*BatchTransactionsResult, error) {
+	if len(args.Operations) > maxBatchOperations {
+		return nil, core.NewError(application.TooManyOperations)
+	}
+	results := make([]BatchTransactionResult, len(args.Operations))
+	operations := make([]services.BatchTransactionArgs, 0)
+	indexes := make([]int, 0)
+	// 同じ取引を複数回更新・削除すると集計から二重に差し引かれるため、2 件目以降はエラーにします
+	ids := make(map[string]bool)
+	for i, operation := range args.Operations {
+		if err := operation.valid(); err != nil {
+			results[i].Error = err
+			continue
+		}
+		if operation.TransactionID != nil && application.BatchOperation(operation.Operation) != application.BatchCreate {
+			if ids[*operation.TransactionID] {
+				results[i].Error = core.NewError(application.DuplicateTransactionID)
+				continue
+			}
+			ids[*operation.TransactionID] = true
+		}
+		operations = append(operations, *operation.convert())
+		indexes = append(indexes, i)
+	}
+	if len(operations) > 0 {
+		res, err := t.service.Batch(&operations)
+		if err != nil {
+			return nil, err
+		}
+		for j, r := range *res {
+			results[indexes[j]] = BatchTransactionResult{
+				TransactionID: r.TransactionID,
+				Error:         r.Error,
+			}
+		}
+	}
+	return &BatchTransactionsResult{Results: results}, nil
+}
+func (t *BatchTransactionArgs) valid() core.Error {
+	err := core.NewError()
+	switch application.BatchOperation(t.Operation) {
+	case application.BatchCreate:
+		if e := t.TransactionArgs.validCreate(); e != nil {
+			err.Concat(e.(core.Error))
+		}
+	case application.BatchUpdate:
+		if t.TransactionID == nil || *t.TransactionID == "" {
+			err.Append(application.RequiredID)
+		}
+		if e := t.TransactionArgs.valid(); e != nil {
+			err.Concat(e.(core.Error))
+		}
+	case application.BatchDelete:
+		if t.TransactionID == nil || *t.TransactionID == "" {
+			err.Append(application.RequiredID)
+		}
+	default:
+		err.Append(application.InValidOperation)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+func (t *BatchTransactionArgs) convert() *services.BatchTransactionArgs {
+	args := &services.BatchTransactionArgs{
+		Operation:     application.BatchOperation(t.Operation),
+		TransactionID: t.TransactionID,
+	}
+	if args.Operation != application.BatchDelete {
+		args.Args = t.TransactionArgs.convert()
+	}
+	return args
+}
+func (t *transactions) GetDuplicates(args *GetDuplicatesArgs) (*GetDuplicatesResult, error) {
+	if args.Days == 0 {
+		args.Days = accountbook.DefaultDuplicateDays
+	}
+	if args.Days < 0 || args.Days > accountbook.MaxDuplicateDays {
+		return nil, core.NewError(application.InValidDuplicateDays)
+	}
+	return t.query.GetDuplicates(args)
+}
+func (t *transactions) Merge(args *MergeTransactionsArgs) error {
+	if err := args.valid(); err != nil {
+		return err
+	}
+	return t.service.Merge(&services.MergeTransactionsArgs{
+		TransactionID:       args.TransactionID,
+		MergeTransactionIDs: args.MergeTransactionIDs,
+	})
+}
+func (t *MergeTransactionsArgs) valid() error {
+	err := core.NewError()
+	if t.TransactionID == "" {
+		err.Append(application.RequiredID)
+	}
+	if len(t.MergeTransactionIDs) == 0 {
+		err.Append(application.RequiredMergeTransactions)
+	}
+	for _, id := range t.MergeTransactionIDs {
+		if id == "" || id == t.TransactionID {
+			err.Append(application.NotDuplicateTransaction)
+			break
+		}
+	}
+	ids := make(map[string]bool)
+	for _, id := range t.MergeTransactionIDs {
+		if ids[id] {
+			err.Append(application.DuplicateTransactionID)
+			break
+		}
+		ids[id] = true
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
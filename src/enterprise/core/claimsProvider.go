@@ -4,5 +4,6 @@ package core
 type ClaimsProvider interface {
 	GetUserID() *string
 	GetEmail() *string
+	GetTimeZone() *string
 	Authenticated() bool
 }
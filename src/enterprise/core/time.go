@@ -5,27 +5,63 @@ import (
 	"time"
 )
 
+// MaxMonthStartDay は月の開始日に指定できる最大の日です
+// 月の区切りを変更した月は長さが変わるため、月の加減算には Clock の AddMonths を使います
+const MaxMonthStartDay = 28
+
+// DefaultLocationName は既定のタイムゾーンです
+const DefaultLocationName = "Asia/Tokyo"
+
 type (
 	clock struct {
-		location *time.Location
+		location      *time.Location
+		monthStartDay int
+		history       []boundary
+	}
+	boundary struct {
+		until         time.Time
+		location      *time.Location
+		monthStartDay int
 	}
 	// ClockOption は option です
 	ClockOption struct {
 		Location string
+		// MonthStartDay は月の開始日です。0 の場合は 1 日とします
+		MonthStartDay int
+		// History は変更前の月の区切りです。Until の昇順に並べます
+		History []MonthBoundary
+	}
+	// MonthBoundary は変更前の月の区切りです
+	// Until より前の月は Location と MonthStartDay で区切ります
+	MonthBoundary struct {
+		Until         time.Time
+		Location      string
+		MonthStartDay int
 	}
 	// Clock は時計です
 	Clock interface {
 		Now() time.Time
 		DefaultLocation() *time.Location
+		MonthStartDay() int
 		GetMonthStartDay(tm *time.Time) time.Time
+		AddMonths(month *time.Time, months int) time.Time
 		GetDay(tm *time.Time) time.Time
 	}
 )
 
+// IsValidLocation はタイムゾーン名が有効かどうかを返します
+// サーバーのタイムゾーンに依存しないよう、空文字と Local は無効とします
+func IsValidLocation(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
 // NewClock is create instance
 func NewClock() Clock {
-	const location = "Asia/Tokyo"
-	return NewClockWithLocation(ClockOption{location})
+	return NewClockWithLocation(ClockOption{Location: DefaultLocationName})
 }
 
 // NewClockWithLocation is create instance with option
@@ -34,7 +70,22 @@ func NewClockWithLocation(option ClockOption) Clock {
 	if err != nil {
 		log.Fatal(err)
 	}
-	return &clock{location: loc}
+	history := make([]boundary, len(option.History))
+	for i, h := range option.History {
+		l, err := time.LoadLocation(h.Location)
+		if err != nil {
+			log.Fatal(err)
+		}
+		history[i] = boundary{until: h.Until, location: l, monthStartDay: validMonthStartDay(h.MonthStartDay)}
+	}
+	return &clock{location: loc, monthStartDay: validMonthStartDay(option.MonthStartDay), history: history}
+}
+
+func validMonthStartDay(monthStartDay int) int {
+	if monthStartDay < 1 || monthStartDay > MaxMonthStartDay {
+		return 1
+	}
+	return monthStartDay
 }
 
 func (t *clock) Now() time.Time {
@@ -43,12 +94,57 @@ func (t *clock) Now() time.Time {
 func (t *clock) DefaultLocation() *time.Location {
 	return t.location
 }
+func (t *clock) MonthStartDay() int {
+	return t.monthStartDay
+}
+
+// GetMonthStartDay は日時が属する月の開始日を返します
+// 月の開始日が 25 日の場合、1月10日は12月25日から始まる月に属します
+// 月の区切りを変更する前の日時は、変更前の月の開始日とタイムゾーンで区切ります
 func (t *clock) GetMonthStartDay(tm *time.Time) time.Time {
 	if tm == nil {
 		now := t.Now()
 		tm = &now
 	}
-	return time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, t.location)
+	local := time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), t.location)
+	b := t.boundaryOf(local)
+	in := local.In(b.location)
+	month := in.Month()
+	if in.Day() < b.monthStartDay {
+		month--
+	}
+	return time.Date(in.Year(), month, b.monthStartDay, 0, 0, 0, 0, b.location).In(t.location)
+}
+
+// AddMonths は月の開始日から months か月後の月の開始日を返します
+// 月の区切りを変更した月は、変更前の区切りから変更後の最初の区切りまでを 1 か月とします
+func (t *clock) AddMonths(month *time.Time, months int) time.Time {
+	start := t.GetMonthStartDay(month)
+	for ; months > 0; months-- {
+		b := t.boundaryOf(start)
+		in := start.In(b.location)
+		next := time.Date(in.Year(), in.Month()+1, b.monthStartDay, 0, 0, 0, 0, b.location)
+		if !b.until.IsZero() && next.After(b.until) {
+			next = b.until
+		}
+		start = next.In(t.location)
+	}
+	for ; months < 0; months++ {
+		previous := start.Add(-time.Nanosecond)
+		start = t.GetMonthStartDay(&previous)
+	}
+	return start
+}
+
+// boundaryOf は日時に適用する月の区切りを返します
+// 現在の区切りの until はゼロ値です
+func (t *clock) boundaryOf(tm time.Time) boundary {
+	for _, b := range t.history {
+		if tm.Before(b.until) {
+			return b
+		}
+	}
+	return boundary{location: t.location, monthStartDay: t.monthStartDay}
 }
 func (t *clock) GetDay(tm *time.Time) time.Time {
 	if tm == nil {
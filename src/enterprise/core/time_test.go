@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockMonthBoundaryHistory(t *testing.T) {
+	utc := func(year int, month time.Month, day int, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		option ClockOption
+		month  time.Time
+		months int
+		want   time.Time
+	}{
+		{
+			name: "変更前の月は変更前の開始日で区切る",
+			option: ClockOption{Location: "UTC", MonthStartDay: 15, History: []MonthBoundary{
+				{Until: utc(2024, 1, 15, 0), Location: "UTC", MonthStartDay: 1},
+			}},
+			month: utc(2023, 12, 20, 0),
+			want:  utc(2023, 12, 1, 0),
+		},
+		{
+			name: "変更した月は変更後の最初の開始日の前日まで続く",
+			option: ClockOption{Location: "UTC", MonthStartDay: 15, History: []MonthBoundary{
+				{Until: utc(2024, 1, 15, 0), Location: "UTC", MonthStartDay: 1},
+			}},
+			month: utc(2024, 1, 10, 0),
+			want:  utc(2024, 1, 1, 0),
+		},
+		{
+			name: "変更後の月は変更後の開始日で区切る",
+			option: ClockOption{Location: "UTC", MonthStartDay: 15, History: []MonthBoundary{
+				{Until: utc(2024, 1, 15, 0), Location: "UTC", MonthStartDay: 1},
+			}},
+			month: utc(2024, 1, 20, 0),
+			want:  utc(2024, 1, 15, 0),
+		},
+		{
+			name: "変更した月をまたいで月を進める",
+			option: ClockOption{Location: "UTC", MonthStartDay: 15, History: []MonthBoundary{
+				{Until: utc(2024, 1, 15, 0), Location: "UTC", MonthStartDay: 1},
+			}},
+			month:  utc(2023, 12, 1, 0),
+			months: 3,
+			want:   utc(2024, 2, 15, 0),
+		},
+		{
+			name: "変更した月をまたいで月を戻す",
+			option: ClockOption{Location: "UTC", MonthStartDay: 15, History: []MonthBoundary{
+				{Until: utc(2024, 1, 15, 0), Location: "UTC", MonthStartDay: 1},
+			}},
+			month:  utc(2024, 2, 15, 0),
+			months: -2,
+			want:   utc(2024, 1, 1, 0),
+		},
+		{
+			name: "変更前のタイムゾーンで区切った月の翌月は変更後の開始日から始まる",
+			option: ClockOption{Location: "UTC", MonthStartDay: 1, History: []MonthBoundary{
+				{Until: utc(2024, 1, 1, 0), Location: "Asia/Tokyo", MonthStartDay: 1},
+			}},
+			month:  time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo).In(time.UTC),
+			months: 1,
+			want:   utc(2024, 1, 1, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewClockWithLocation(tt.option)
+			got := clock.AddMonths(&tt.month, tt.months)
+			if !got.Equal(tt.want) {
+				t.Errorf("AddMonths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package accountbook
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// BuildAggregate は月の取引から集計を作り直します
+// getDay には取引の日時から日付を求める関数を指定します
+func BuildAggregate(month time.Time, transactions []models.Transaction, getDay func(*time.Time) time.Time) *models.Aggregate {
+	aggregate := &models.Aggregate{
+		Month:      month,
+		Daily:      make([]models.Daily, 0),
+		Categories: make([]models.CategorySummary, 0),
+	}
+	for _, transaction := range transactions {
+		tr := transaction
+		ApplyToAggregate(aggregate, &tr, getDay(&tr.Date), 1)
+	}
+	return aggregate
+}
+
+// ApplyToAggregate は取引の金額を集計に加えます
+// sign に -1 を指定すると、更新前や削除した取引の金額を集計から差し引きます
+func ApplyToAggregate(aggregate *models.Aggregate, transaction *models.Transaction, day time.Time, sign int) {
+	amount := transaction.Amount * sign
+	isIncome := transaction.Category == IncomeCategory && !transaction.Uncategorized
+
+	daily := -1
+	for i, d := range aggregate.Daily {
+		if d.Date.Equal(day) {
+			daily = i
+			break
+		}
+	}
+	if daily < 0 {
+		aggregate.Daily = append(aggregate.Daily, models.Daily{Date: day})
+		daily = len(aggregate.Daily) - 1
+	}
+	if isIncome {
+		aggregate.Income += amount
+		aggregate.Daily[daily].Income += amount
+	} else {
+		aggregate.Expense += amount
+		aggregate.Daily[daily].Expense += amount
+	}
+	if aggregate.Daily[daily].Income == 0 && aggregate.Daily[daily].Expense == 0 {
+		aggregate.Daily = append(aggregate.Daily[:daily], aggregate.Daily[daily+1:]...)
+	}
+	sort.SliceStable(aggregate.Daily, func(i, j int) bool {
+		return aggregate.Daily[i].Date.Before(aggregate.Daily[j].Date)
+	})
+
+	// 収入のカテゴリは BreakdownByCategory と同様に含まず、未分類の取引は未分類として集計します
+	if isIncome {
+		return
+	}
+	category := -1
+	for i, c := range aggregate.Categories {
+		if c.Category == transaction.Category && c.Uncategorized == transaction.Uncategorized {
+			category = i
+			break
+		}
+	}
+	if category < 0 {
+		aggregate.Categories = append(aggregate.Categories, models.CategorySummary{
+			Category:      transaction.Category,
+			Uncategorized: transaction.Uncategorized,
+		})
+		category = len(aggregate.Categories) - 1
+	}
+	aggregate.Categories[category].Amount += amount
+	aggregate.Categories[category].Count += sign
+	if aggregate.Categories[category].Count <= 0 {
+		aggregate.Categories = append(aggregate.Categories[:category], aggregate.Categories[category+1:]...)
+	}
+	sortCategorySummaries(aggregate.Categories)
+}
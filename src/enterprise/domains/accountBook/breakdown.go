@@ -0,0 +1,63 @@
+package accountbook
+
+import (
+	"sort"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// categoryKey はカテゴリ毎の集計のキーです
+type categoryKey struct {
+	category      int
+	uncategorized bool
+}
+
+// BreakdownByCategory は取引の支出をカテゴリ毎に集計し、金額の降順で返します
+// 収入のカテゴリは含まず、未分類の取引は未分類として集計します
+func BreakdownByCategory(transactions []models.Transaction) []models.CategorySummary {
+	summaries := make(map[categoryKey]*models.CategorySummary)
+	for _, transaction := range transactions {
+		if transaction.Category == IncomeCategory && !transaction.Uncategorized {
+			continue
+		}
+		key := categoryKey{transaction.Category, transaction.Uncategorized}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &models.CategorySummary{Category: transaction.Category, Uncategorized: transaction.Uncategorized}
+			summaries[key] = summary
+		}
+		summary.Amount += transaction.Amount
+		summary.Count++
+	}
+	result := make([]models.CategorySummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sortCategorySummaries(result)
+	return result
+}
+
+// sortCategorySummaries はカテゴリ毎の集計を金額の降順に並べ替えます
+func sortCategorySummaries(summaries []models.CategorySummary) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Amount == summaries[j].Amount {
+			if summaries[i].Uncategorized != summaries[j].Uncategorized {
+				return !summaries[i].Uncategorized
+			}
+			return summaries[i].Category < summaries[j].Category
+		}
+		return summaries[i].Amount > summaries[j].Amount
+	})
+}
+
+// ExpenseShare はカテゴリ毎の支出の合計に対する割合を返します
+func ExpenseShare(summaries []models.CategorySummary, amount int) float64 {
+	total := 0
+	for _, summary := range summaries {
+		total += summary.Amount
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(amount) / float64(total)
+}
@@ -0,0 +1,55 @@
+package accountbook
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// MaxRolloverMonths は予算の繰越を遡る月数の上限です
+const MaxRolloverMonths = 12
+
+// EffectiveBudget は指定月に有効なカテゴリの予算を返します
+// 指定月の予算がない場合は、それ以前で最も新しい予算を引き継ぎます
+func EffectiveBudget(budgets []models.Budget, category int, month time.Time) *models.Budget {
+	var result *models.Budget
+	for i := range budgets {
+		budget := &budgets[i]
+		if budget.Category != category || budget.Month.After(month) {
+			continue
+		}
+		if result == nil || budget.Month.After(result.Month) {
+			result = budget
+		}
+	}
+	return result
+}
+
+// SpentByCategory は取引をダッシュボードと同様に集計し、カテゴリ毎の支出を返します
+// 予算はカテゴリ毎に設定するため、未分類の取引は含みません
+func SpentByCategory(transactions []models.Transaction) map[int]int {
+	spent := map[int]int{}
+	for _, transaction := range transactions {
+		if transaction.Category == IncomeCategory || transaction.Uncategorized {
+			continue
+		}
+		spent[transaction.Category] += transaction.Amount
+	}
+	return spent
+}
+
+// Rollover は前月までの予算の繰越額を返します
+// months は対象月の前月から遡った月の開始日、spent はそれぞれの月のカテゴリ毎の支出です
+func Rollover(budgets []models.Budget, category int, months []time.Time, spent []map[int]int) int {
+	// 古い月から順に残額を積み上げます
+	carry := 0
+	for i := len(months) - 1; i >= 0; i-- {
+		budget := EffectiveBudget(budgets, category, months[i])
+		if budget == nil || !budget.Rollover {
+			carry = 0
+			continue
+		}
+		carry += budget.Amount - spent[i][category]
+	}
+	return carry
+}
@@ -0,0 +1,102 @@
+package accountbook
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// IncomeCategory は収入を表すカテゴリです
+const IncomeCategory = 5
+
+// Categorize はルールを優先度順に適用し、取引の未設定の項目を埋めます。
+// カテゴリは未分類の取引にのみ設定します。
+// payees は支払先 ID と支払先名の対応です。変更があった場合 true を返します
+func Categorize(rules []models.CategorizationRule, transaction *models.Transaction, payees map[string]string) bool {
+	sorted := make([]models.CategorizationRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority < sorted[j].Priority })
+
+	changed := false
+	for _, rule := range sorted {
+		if !MatchRule(&rule, transaction, payeeName(transaction, payees)) {
+			continue
+		}
+		if rule.Category != nil && transaction.Uncategorized {
+			transaction.Category = *rule.Category
+			transaction.Uncategorized = false
+			changed = true
+		}
+		if rule.PayeeID != nil && transaction.PayeeID == nil {
+			payeeID := *rule.PayeeID
+			transaction.PayeeID = &payeeID
+			changed = true
+		}
+		for _, tag := range rule.Tags {
+			if !containsTag(transaction.Tags, tag) {
+				transaction.Tags = append(transaction.Tags, tag)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// MatchRule は取引がルールの条件をすべて満たすかを判定します。条件のないルールはどの取引にも一致しません
+func MatchRule(rule *models.CategorizationRule, transaction *models.Transaction, payeeName string) bool {
+	if !HasRuleCondition(rule) {
+		return false
+	}
+	if rule.NotesPattern != nil && !containsText(transaction.Notes, *rule.NotesPattern) {
+		return false
+	}
+	if rule.PayeePattern != nil && !containsText(&payeeName, *rule.PayeePattern) {
+		return false
+	}
+	if rule.MinAmount != nil && transaction.Amount < *rule.MinAmount {
+		return false
+	}
+	if rule.MaxAmount != nil && transaction.Amount > *rule.MaxAmount {
+		return false
+	}
+	if rule.ImportSource != nil && (transaction.ImportSource == nil || *transaction.ImportSource != *rule.ImportSource) {
+		return false
+	}
+	return true
+}
+
+// HasRuleCondition はルールに条件が設定されているかを判定します
+func HasRuleCondition(rule *models.CategorizationRule) bool {
+	return rule.NotesPattern != nil ||
+		rule.PayeePattern != nil ||
+		rule.MinAmount != nil ||
+		rule.MaxAmount != nil ||
+		rule.ImportSource != nil
+}
+
+// HasRuleAction はルールに設定内容があるかを判定します
+func HasRuleAction(rule *models.CategorizationRule) bool {
+	return rule.Category != nil || rule.PayeeID != nil || len(rule.Tags) > 0
+}
+
+func payeeName(transaction *models.Transaction, payees map[string]string) string {
+	if transaction.PayeeID == nil {
+		return ""
+	}
+	return payees[*transaction.PayeeID]
+}
+func containsText(text *string, pattern string) bool {
+	if text == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(*text), strings.ToLower(pattern))
+}
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
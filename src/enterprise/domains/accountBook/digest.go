@@ -0,0 +1,37 @@
+package accountbook
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// MissingActualPlans は実績が未入力の計画を返します
+func MissingActualPlans(plans []models.Plan, actuals []models.Actual) []models.Plan {
+	captured := make(map[string]bool)
+	for _, actual := range actuals {
+		captured[actual.PlanID] = true
+	}
+	missing := make([]models.Plan, 0)
+	for _, plan := range plans {
+		if !captured[plan.PlanID] {
+			missing = append(missing, plan)
+		}
+	}
+	return missing
+}
+
+// SumExpense は開始日時から終了日時(含まない)までの取引の支出の合計を返します
+func SumExpense(transactions []models.Transaction, start time.Time, end time.Time) int {
+	expense := 0
+	for _, transaction := range transactions {
+		if transaction.Category == IncomeCategory {
+			continue
+		}
+		if transaction.Date.Before(start) || !transaction.Date.Before(end) {
+			continue
+		}
+		expense += transaction.Amount
+	}
+	return expense
+}
@@ -0,0 +1,133 @@
+package accountbook
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+const (
+	// DefaultDuplicateDays は重複とみなす日数の既定値です
+	DefaultDuplicateDays = 3
+	// MaxDuplicateDays は重複とみなす日数の上限です
+	MaxDuplicateDays = 31
+	// similarNotesRate は備考を類似とみなす編集距離の割合です
+	similarNotesRate = 0.3
+)
+
+// IsDuplicate は2つの取引が重複している可能性があるかを判定します
+func IsDuplicate(a *models.Transaction, b *models.Transaction, days int) bool {
+	if a.Amount != b.Amount || a.Category != b.Category || a.Uncategorized != b.Uncategorized {
+		return false
+	}
+	diff := a.Date.Sub(b.Date)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > time.Duration(days)*24*time.Hour {
+		return false
+	}
+	if a.ImportReference != nil && b.ImportReference != nil {
+		// 取込元が同じ明細を指しているかどうかで判断する
+		return *a.ImportReference == *b.ImportReference
+	}
+	return isSimilarNotes(a.Notes, b.Notes)
+}
+
+// GroupDuplicates は重複の可能性がある取引をグループにまとめます
+func GroupDuplicates(transactions []models.Transaction, days int) [][]models.Transaction {
+	parents := make([]int, len(transactions))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	for i := range transactions {
+		for j := i + 1; j < len(transactions); j++ {
+			if IsDuplicate(&transactions[i], &transactions[j], days) {
+				parents[find(j)] = find(i)
+			}
+		}
+	}
+
+	groupMap := make(map[int][]models.Transaction)
+	roots := make([]int, 0)
+	for i, transaction := range transactions {
+		root := find(i)
+		if _, ok := groupMap[root]; !ok {
+			roots = append(roots, root)
+		}
+		groupMap[root] = append(groupMap[root], transaction)
+	}
+	groups := make([][]models.Transaction, 0)
+	for _, root := range roots {
+		group := groupMap[root]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Date.Before(group[j].Date) })
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// isSimilarNotes は備考が類似しているかを判定します
+// 両方が未入力の場合は類似とみなし、片方のみ未入力の場合は類似とみなしません
+func isSimilarNotes(a *string, b *string) bool {
+	x := normalizeNotes(a)
+	y := normalizeNotes(b)
+	if x == "" || y == "" {
+		return x == y
+	}
+	if strings.Contains(x, y) || strings.Contains(y, x) {
+		return true
+	}
+	rx := []rune(x)
+	ry := []rune(y)
+	length := len(rx)
+	if len(ry) > length {
+		length = len(ry)
+	}
+	return float64(levenshtein(rx, ry)) <= float64(length)*similarNotesRate
+}
+func normalizeNotes(notes *string) string {
+	if notes == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(strings.ToLower(*notes)), " ")
+}
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
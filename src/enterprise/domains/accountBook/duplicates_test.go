@@ -0,0 +1,123 @@
+package accountbook
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+func stringPtr(value string) *string { return &value }
+
+func TestIsDuplicate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *models.Transaction, b *models.Transaction)
+		want   bool
+	}{
+		{"同じ取引は重複とみなす", func(a, b *models.Transaction) {}, true},
+		{"日数の範囲内は重複とみなす", func(a, b *models.Transaction) { b.Date = date(2024, 3, 13) }, true},
+		{"日数の範囲外は重複とみなさない", func(a, b *models.Transaction) { b.Date = date(2024, 3, 14) }, false},
+		{"金額が異なる場合は重複とみなさない", func(a, b *models.Transaction) { b.Amount = 1201 }, false},
+		{"カテゴリが異なる場合は重複とみなさない", func(a, b *models.Transaction) { b.Category = 2 }, false},
+		{"未分類かどうかが異なる場合は重複とみなさない", func(a, b *models.Transaction) { b.Uncategorized = true }, false},
+		{"大文字小文字と空白の違いは無視する", func(a, b *models.Transaction) { b.Notes = stringPtr("  STARBUCKS   Coffee ") }, true},
+		{"一方の備考を含む場合は類似とみなす", func(a, b *models.Transaction) { b.Notes = stringPtr("starbucks") }, true},
+		{"編集距離が割合以内の場合は類似とみなす", func(a, b *models.Transaction) { b.Notes = stringPtr("starbucks cofee") }, true},
+		{"異なる備考は類似とみなさない", func(a, b *models.Transaction) { b.Notes = stringPtr("Amazon") }, false},
+		{"一方のみ備考が未入力の場合は類似とみなさない", func(a, b *models.Transaction) { b.Notes = nil }, false},
+		{
+			"取込元が同じ明細の場合は備考に関わらず重複とみなす",
+			func(a, b *models.Transaction) {
+				a.ImportReference = stringPtr("ref-1")
+				b.ImportReference = stringPtr("ref-1")
+				b.Notes = stringPtr("Amazon")
+			},
+			true,
+		},
+		{
+			"取込元の明細が異なる場合は備考が同じでも重複とみなさない",
+			func(a, b *models.Transaction) {
+				a.ImportReference = stringPtr("ref-1")
+				b.ImportReference = stringPtr("ref-2")
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := models.Transaction{Amount: 1200, Category: 1, Date: date(2024, 3, 10), Notes: stringPtr("Starbucks coffee")}
+			b := a
+			tt.modify(&a, &b)
+			if got := IsDuplicate(&a, &b, DefaultDuplicateDays); got != tt.want {
+				t.Errorf("IsDuplicate() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSimilarNotes(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *string
+		b    *string
+		want bool
+	}{
+		{"両方が未入力の場合は類似とみなす", nil, stringPtr(" "), true},
+		{"一方のみ未入力の場合は類似とみなさない", stringPtr("coffee"), nil, false},
+		{"編集距離が割合と等しい場合は類似とみなす", stringPtr("abcdefghij"), stringPtr("abcdefgxyz"), true},
+		{"編集距離が割合を超える場合は類似とみなさない", stringPtr("abcdefghij"), stringPtr("abcdefwxyz"), false},
+		{"マルチバイト文字は文字単位で比べる", stringPtr("スターバックス"), stringPtr("スタバックス"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSimilarNotes(tt.a, tt.b); got != tt.want {
+				t.Errorf("isSimilarNotes() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupDuplicates(t *testing.T) {
+	transaction := func(id string, day int, amount int) models.Transaction {
+		return models.Transaction{TransactionID: id, Amount: amount, Category: 1, Date: date(2024, 3, day)}
+	}
+	tests := []struct {
+		name         string
+		transactions []models.Transaction
+		want         [][]string
+	}{
+		{
+			name:         "重複の重複は同じグループにまとめる",
+			transactions: []models.Transaction{transaction("c", 7, 1000), transaction("a", 1, 1000), transaction("b", 4, 1000)},
+			want:         [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:         "重複のない取引はグループにしない",
+			transactions: []models.Transaction{transaction("a", 1, 1000), transaction("b", 2, 2000), transaction("c", 9, 1000)},
+			want:         [][]string{},
+		},
+		{
+			name: "別々の重複は別のグループにする",
+			transactions: []models.Transaction{
+				transaction("a", 1, 1000), transaction("b", 2, 2000), transaction("c", 2, 1000), transaction("d", 3, 2000),
+			},
+			want: [][]string{{"a", "c"}, {"b", "d"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([][]string, 0)
+			for _, group := range GroupDuplicates(tt.transactions, DefaultDuplicateDays) {
+				ids := make([]string, len(group))
+				for i, transaction := range group {
+					ids[i] = transaction.TransactionID
+				}
+				got = append(got, ids)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupDuplicates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
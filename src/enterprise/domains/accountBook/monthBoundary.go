@@ -0,0 +1,107 @@
+package accountbook
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// UserClockOption はユーザーの設定から時計の option を作成します
+// タイムゾーンはユーザーの設定、トークンのタイムゾーン、既定のタイムゾーンの順に使います
+// 月の区切りを変更する前の月は、ユーザーに保存した変更前の区切りで区切ります
+func UserClockOption(user *models.User, tokenTimeZone string) core.ClockOption {
+	option := core.ClockOption{
+		Location:      userLocation(user.TimeZone, tokenTimeZone),
+		MonthStartDay: user.MonthStartDay,
+		History:       make([]core.MonthBoundary, len(user.MonthSettingsHistory)),
+	}
+	for i, settings := range user.MonthSettingsHistory {
+		location := settings.TimeZone
+		if !core.IsValidLocation(location) {
+			location = option.Location
+		}
+		option.History[i] = core.MonthBoundary{
+			Until:         settings.Until,
+			Location:      location,
+			MonthStartDay: settings.MonthStartDay,
+		}
+	}
+	return option
+}
+
+func userLocation(timeZone string, tokenTimeZone string) string {
+	if core.IsValidLocation(timeZone) {
+		return timeZone
+	}
+	if core.IsValidLocation(tokenTimeZone) {
+		return tokenTimeZone
+	}
+	return core.DefaultLocationName
+}
+
+// ChangeMonthBoundary は apply でユーザーの月の区切りを変更し、実績を入力中の月を新しい区切りに移します
+// 締め済みの月はそのままとし、締めていない最初の月の次の区切りから新しい月の区切りを適用します
+// 変更前の区切りはユーザーに保存し、適用日より前の月は変更前の区切りで区切ります
+// 集計は新しい月の区切りで作り直すよう削除します
+func ChangeMonthBoundary(boundary *models.MonthBoundary, apply func(user *models.User), tokenTimeZone string) {
+	current := boundary.User
+	oldOption := UserClockOption(&current, tokenTimeZone)
+	oldClock := core.NewClockWithLocation(oldOption)
+	next := current
+	apply(&next)
+	newOption := UserClockOption(&next, tokenTimeZone)
+	if newOption.Location == oldOption.Location && core.NewClockWithLocation(newOption).MonthStartDay() == oldClock.MonthStartDay() {
+		// 月の区切りが変わらない場合は移す月がありません
+		boundary.User = next
+		return
+	}
+
+	// 締めていない最初の月から新しい月の区切りを適用する
+	from := oldClock.GetMonthStartDay(nil)
+	if boundary.LatestClosed != nil {
+		date := boundary.LatestClosed.Date.In(oldClock.DefaultLocation())
+		from = oldClock.AddMonths(&date, 1)
+	} else if len(boundary.Open) > 0 {
+		date := boundary.Open[0].Date.In(oldClock.DefaultLocation())
+		from = oldClock.GetMonthStartDay(&date)
+	}
+
+	// from の月に適用していた区切りを、新しい区切りの最初の開始日までの区切りとして残す
+	previous := models.MonthSettings{
+		MonthStartDay: current.MonthStartDay,
+		TimeZone:      oldOption.Location,
+	}
+	history := make([]models.MonthSettings, 0, len(current.MonthSettingsHistory)+1)
+	for _, settings := range current.MonthSettingsHistory {
+		if !settings.Until.After(from) {
+			history = append(history, settings)
+			continue
+		}
+		previous = settings
+		break
+	}
+	next.MonthSettingsHistory = history
+	newClock := core.NewClockWithLocation(UserClockOption(&next, tokenTimeZone))
+	until := from.In(newClock.DefaultLocation())
+	if until = newClock.GetMonthStartDay(&until); !until.After(from) {
+		until = newClock.AddMonths(&until, 1)
+	}
+	previous.Until = until
+	next.MonthSettingsHistory = append(history, previous)
+	newClock = core.NewClockWithLocation(UserClockOption(&next, tokenTimeZone))
+
+	// 実績を入力中の月は、from から何か月目かを保ったまま新しい区切りの開始日に移す
+	for i := range boundary.Open {
+		date := boundary.Open[i].Date.In(oldClock.DefaultLocation())
+		if date.Before(from) {
+			continue
+		}
+		months := 0
+		for month := from; month.Before(date); month = oldClock.AddMonths(&month, 1) {
+			months++
+		}
+		start := from.In(newClock.DefaultLocation())
+		boundary.Open[i].Date = newClock.AddMonths(&start, months)
+	}
+	boundary.User = next
+	boundary.ClearAggregates = true
+}
@@ -0,0 +1,95 @@
+package accountbook
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+func TestChangeMonthBoundary(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      models.User
+		apply     func(user *models.User)
+		open      []time.Time
+		wantOpen  []time.Time
+		wantUser  models.User
+		wantClear bool
+	}{
+		{
+			name:      "区切りが変わらない場合は月を移さない",
+			user:      models.User{MonthStartDay: 1, TimeZone: "UTC"},
+			apply:     func(user *models.User) { user.MonthStartDay = 1 },
+			open:      []time.Time{date(2024, 1, 1), date(2024, 2, 1)},
+			wantOpen:  []time.Time{date(2024, 1, 1), date(2024, 2, 1)},
+			wantUser:  models.User{MonthStartDay: 1, TimeZone: "UTC"},
+			wantClear: false,
+		},
+		{
+			name:     "締めていない最初の月の次の区切りから新しい開始日を適用する",
+			user:     models.User{MonthStartDay: 1, TimeZone: "UTC"},
+			apply:    func(user *models.User) { user.MonthStartDay = 15 },
+			open:     []time.Time{date(2024, 1, 1), date(2024, 2, 1), date(2024, 3, 1)},
+			wantOpen: []time.Time{date(2024, 1, 1), date(2024, 1, 15), date(2024, 2, 15)},
+			wantUser: models.User{MonthStartDay: 15, TimeZone: "UTC", MonthSettingsHistory: []models.MonthSettings{
+				{Until: date(2024, 1, 15), MonthStartDay: 1, TimeZone: "UTC"},
+			}},
+			wantClear: true,
+		},
+		{
+			name: "適用前に再び変更した場合は変更前の区切りの適用日を置き換える",
+			user: models.User{MonthStartDay: 15, TimeZone: "UTC", MonthSettingsHistory: []models.MonthSettings{
+				{Until: date(2024, 1, 15), MonthStartDay: 1, TimeZone: "UTC"},
+			}},
+			apply:    func(user *models.User) { user.MonthStartDay = 10 },
+			open:     []time.Time{date(2024, 1, 1), date(2024, 1, 15)},
+			wantOpen: []time.Time{date(2024, 1, 1), date(2024, 1, 10)},
+			wantUser: models.User{MonthStartDay: 10, TimeZone: "UTC", MonthSettingsHistory: []models.MonthSettings{
+				{Until: date(2024, 1, 10), MonthStartDay: 1, TimeZone: "UTC"},
+			}},
+			wantClear: true,
+		},
+		{
+			name:     "タイムゾーンだけを変更した場合も月を移す",
+			user:     models.User{MonthStartDay: 1, TimeZone: "UTC"},
+			apply:    func(user *models.User) { user.TimeZone = "Asia/Tokyo" },
+			open:     []time.Time{date(2024, 1, 1), date(2024, 2, 1)},
+			wantOpen: []time.Time{date(2024, 1, 1), date(2024, 1, 31).Add(15 * time.Hour)},
+			wantUser: models.User{MonthStartDay: 1, TimeZone: "Asia/Tokyo", MonthSettingsHistory: []models.MonthSettings{
+				{Until: date(2024, 1, 31).Add(15 * time.Hour), MonthStartDay: 1, TimeZone: "UTC"},
+			}},
+			wantClear: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boundary := &models.MonthBoundary{
+				User:         tt.user,
+				LatestClosed: &models.Dashboard{Date: date(2023, 12, 1), State: "closed"},
+				Open:         make([]models.Dashboard, len(tt.open)),
+			}
+			for i, d := range tt.open {
+				boundary.Open[i] = models.Dashboard{Date: d, State: "open"}
+			}
+			ChangeMonthBoundary(boundary, tt.apply, "")
+
+			for i, want := range tt.wantOpen {
+				if got := boundary.Open[i].Date; !got.Equal(want) {
+					t.Errorf("Open[%d] = %v, want %v", i, got, want)
+				}
+			}
+			if boundary.ClearAggregates != tt.wantClear {
+				t.Errorf("ClearAggregates = %t, want %t", boundary.ClearAggregates, tt.wantClear)
+			}
+			user := boundary.User
+			for i := range user.MonthSettingsHistory {
+				user.MonthSettingsHistory[i].Until = user.MonthSettingsHistory[i].Until.UTC()
+			}
+			if !reflect.DeepEqual(user, tt.wantUser) {
+				t.Errorf("User = %+v, want %+v", user, tt.wantUser)
+			}
+		})
+	}
+}
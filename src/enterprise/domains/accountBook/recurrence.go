@@ -0,0 +1,232 @@
+package accountbook
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+const (
+	// FrequencyWeekly は毎週の繰り返しです
+	FrequencyWeekly = "weekly"
+	// FrequencyMonthly は毎月の繰り返しです
+	FrequencyMonthly = "monthly"
+	// FrequencyYearly は毎年の繰り返しです
+	FrequencyYearly = "yearly"
+)
+
+// IsValidFrequency は繰り返し頻度が有効かどうかを返します
+func IsValidFrequency(frequency string) bool {
+	switch frequency {
+	case FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	}
+	return false
+}
+
+// RecurrenceOf は計画の繰り返し規則を返します
+// 繰り返し規則が未設定の旧データは Interval から毎月の規則に変換します
+func RecurrenceOf(plan *models.Plan) *models.Recurrence {
+	if plan.Recurrence != nil {
+		return plan.Recurrence
+	}
+	interval := plan.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	return &models.Recurrence{
+		Frequency: FrequencyMonthly,
+		Interval:  interval,
+	}
+}
+
+// MigrateRecurrence は旧データの Interval を繰り返し規則に移行します
+func MigrateRecurrence(plan *models.Plan) bool {
+	if plan.Recurrence != nil {
+		return false
+	}
+	plan.Recurrence = RecurrenceOf(plan)
+	return true
+}
+
+// LegacyInterval は旧データの Interval (月数) として保存する値を返します
+// 毎年の繰り返しは月数に換算し、月数で表せない毎週の繰り返しは毎月とします
+func LegacyInterval(recurrence *models.Recurrence) int {
+	interval := recurrence.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	switch recurrence.Frequency {
+	case FrequencyWeekly:
+		return 1
+	case FrequencyYearly:
+		return interval * 12
+	}
+	return interval
+}
+
+// PlanStart は計画の繰り返しの起点を返します
+func PlanStart(plan *models.Plan) time.Time {
+	if plan.Start != nil {
+		return *plan.Start
+	}
+	return plan.CreatedAt
+}
+
+// Occurrences は [from, to) の期間に発生する計画の日付を返します
+// 日の指定がない月単位の繰り返しは、期間の開始日時を月の開始日として月の開始日に発生します
+// 月の区切りを変更した月は 1 か月と長さが異なるため、期間は月の開始日から翌月の開始日までを渡します
+func Occurrences(plan *models.Plan, from, to time.Time) []time.Time {
+	result := make([]time.Time, 0)
+	if plan.End != nil && !plan.End.After(from) {
+		return result
+	}
+	if plan.End != nil && plan.End.Before(to) {
+		to = *plan.End
+	}
+	r := RecurrenceOf(plan)
+	// Firestore から読み込んだ日時は UTC のため、期間のタイムゾーンで月や日を判定します
+	start := PlanStart(plan).In(from.Location())
+	if r.Frequency != FrequencyWeekly && len(r.ByMonthDay) == 0 {
+		// 日の指定がない月単位の繰り返しは、従来どおり月の開始日に発生したものとして扱います
+		start = monthStart(start, from)
+	}
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	// Count は起点からの発生回数なので、期間外の発生も数える必要があります
+	count := 0
+	emit := func(date time.Time) bool {
+		if date.Before(start) {
+			return true
+		}
+		if !date.Before(to) || (r.Until != nil && date.After(*r.Until)) {
+			return false
+		}
+		if r.Count != nil && count >= *r.Count {
+			return false
+		}
+		count++
+		if !date.Before(from) {
+			result = append(result, date)
+		}
+		return true
+	}
+	switch r.Frequency {
+	case FrequencyWeekly:
+		for date := start; ; date = date.AddDate(0, 0, 7*interval) {
+			if !matchMonth(r.ByMonth, date.Month()) {
+				if !date.Before(to) {
+					return result
+				}
+				continue
+			}
+			if !emit(date) {
+				return result
+			}
+		}
+	case FrequencyYearly:
+		for year := start.Year(); ; year += interval {
+			if year > to.Year() {
+				return result
+			}
+			months := r.ByMonth
+			if len(months) == 0 {
+				months = []int{int(start.Month())}
+			}
+			for month := time.January; month <= time.December; month++ {
+				if !matchMonth(months, month) {
+					continue
+				}
+				for _, date := range monthDays(r.ByMonthDay, year, month, start) {
+					if !emit(date) {
+						return result
+					}
+				}
+			}
+		}
+	default:
+		for month := firstDayOfMonth(start); ; month = month.AddDate(0, interval, 0) {
+			if !month.Before(to) {
+				return result
+			}
+			if !matchMonth(r.ByMonth, month.Month()) {
+				continue
+			}
+			for _, date := range monthDays(r.ByMonthDay, month.Year(), month.Month(), start) {
+				if !emit(date) {
+					return result
+				}
+			}
+		}
+	}
+}
+
+// PlansInMonth は [month, next) の月に発生する計画を、その月に有効な改定を反映して返します
+// 月内に複数回発生する計画 (毎週など) は発生回数分の金額を計上します
+func PlansInMonth(plans []models.Plan, month, next time.Time) []models.Plan {
+	result := make([]models.Plan, 0)
+	for _, p := range plans {
+		plan := p
+		ApplyRevision(&plan, month)
+		occurrences := Occurrences(&plan, month, next)
+		if len(occurrences) > 0 {
+			plan.PlanAmount *= len(occurrences)
+			result = append(result, plan)
+		}
+	}
+	return result
+}
+
+func matchMonth(months []int, month time.Month) bool {
+	if len(months) == 0 {
+		return true
+	}
+	for _, m := range months {
+		if m == int(month) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays は指定月の発生日を昇順で返します
+// 負の日付は月末からの日数とし、月の日数を超える日付は月末に丸めます
+func monthDays(byMonthDay []int, year int, month time.Month, start time.Time) []time.Time {
+	days := byMonthDay
+	if len(days) == 0 {
+		days = []int{start.Day()}
+	}
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, start.Location()).Day()
+	seen := map[int]bool{}
+	dates := make([]time.Time, 0, len(days))
+	for d := 1; d <= last; d++ {
+		for _, day := range days {
+			if day < 0 {
+				day = last + day + 1
+			}
+			if day > last {
+				day = last
+			}
+			if day == d && !seen[d] {
+				seen[d] = true
+				dates = append(dates, time.Date(year, month, d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()))
+			}
+		}
+	}
+	return dates
+}
+
+func firstDayOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// monthStart は日時が属する、from と同じ日時から始まる月の開始日を返します
+func monthStart(t time.Time, from time.Time) time.Time {
+	month := t.Month()
+	if t.Day() < from.Day() {
+		month--
+	}
+	return time.Date(t.Year(), month, from.Day(), from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), t.Location())
+}
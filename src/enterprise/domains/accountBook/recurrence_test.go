@@ -0,0 +1,163 @@
+package accountbook
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+func datePtr(year int, month time.Month, day int) *time.Time {
+	d := date(year, month, day)
+	return &d
+}
+func intPtr(value int) *int { return &value }
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name string
+		plan models.Plan
+		from time.Time
+		to   time.Time
+		want []time.Time
+	}{
+		{
+			name: "旧データの毎月は月の開始日に発生する",
+			plan: models.Plan{Interval: 1, Start: datePtr(2024, 1, 10)},
+			from: date(2024, 3, 1),
+			to:   date(2024, 4, 1),
+			want: []time.Time{date(2024, 3, 1)},
+		},
+		{
+			name: "2か月毎は間の月に発生しない",
+			plan: models.Plan{Interval: 2, Start: datePtr(2024, 1, 10)},
+			from: date(2024, 2, 1),
+			to:   date(2024, 3, 1),
+			want: []time.Time{},
+		},
+		{
+			name: "日の指定は月末からの日数と月末への丸めに対応する",
+			plan: models.Plan{Start: datePtr(2024, 1, 1), Recurrence: &models.Recurrence{
+				Frequency: FrequencyMonthly, Interval: 1, ByMonthDay: []int{15, -1, 31},
+			}},
+			from: date(2024, 2, 1),
+			to:   date(2024, 3, 1),
+			want: []time.Time{date(2024, 2, 15), date(2024, 2, 29)},
+		},
+		{
+			name: "月の指定は指定した月のみ発生する",
+			plan: models.Plan{Start: datePtr(2024, 1, 1), Recurrence: &models.Recurrence{
+				Frequency: FrequencyMonthly, Interval: 1, ByMonth: []int{3, 9},
+			}},
+			from: date(2024, 3, 1),
+			to:   date(2024, 5, 1),
+			want: []time.Time{date(2024, 3, 1)},
+		},
+		{
+			name: "回数は起点から数える",
+			plan: models.Plan{Start: datePtr(2024, 1, 1), Recurrence: &models.Recurrence{
+				Frequency: FrequencyMonthly, Interval: 1, Count: intPtr(3),
+			}},
+			from: date(2024, 3, 1),
+			to:   date(2024, 5, 1),
+			want: []time.Time{date(2024, 3, 1)},
+		},
+		{
+			name: "毎週",
+			plan: models.Plan{Start: datePtr(2024, 3, 4), Recurrence: &models.Recurrence{
+				Frequency: FrequencyWeekly, Interval: 1,
+			}},
+			from: date(2024, 3, 1),
+			to:   date(2024, 4, 1),
+			want: []time.Time{date(2024, 3, 4), date(2024, 3, 11), date(2024, 3, 18), date(2024, 3, 25)},
+		},
+		{
+			name: "2週毎と終了日",
+			plan: models.Plan{Start: datePtr(2024, 2, 19), Recurrence: &models.Recurrence{
+				Frequency: FrequencyWeekly, Interval: 2, Until: datePtr(2024, 3, 20),
+			}},
+			from: date(2024, 3, 1),
+			to:   date(2024, 4, 1),
+			want: []time.Time{date(2024, 3, 4), date(2024, 3, 18)},
+		},
+		{
+			name: "毎年は起点の月日に発生する",
+			plan: models.Plan{Start: datePtr(2023, 6, 10), Recurrence: &models.Recurrence{
+				Frequency: FrequencyYearly, Interval: 1, ByMonthDay: []int{10},
+			}},
+			from: date(2024, 1, 1),
+			to:   date(2025, 1, 1),
+			want: []time.Time{date(2024, 6, 10)},
+		},
+		{
+			name: "月の開始日が 25 日の場合は 25 日に発生する",
+			plan: models.Plan{Interval: 1, Start: datePtr(2024, 1, 10)},
+			from: date(2024, 2, 25),
+			to:   date(2024, 3, 25),
+			want: []time.Time{date(2024, 2, 25)},
+		},
+		{
+			name: "月の開始日が 25 日でも日の指定はその日に発生する",
+			plan: models.Plan{Start: datePtr(2024, 1, 10), Recurrence: &models.Recurrence{
+				Frequency: FrequencyMonthly, Interval: 1, ByMonthDay: []int{10},
+			}},
+			from: date(2024, 2, 25),
+			to:   date(2024, 3, 25),
+			want: []time.Time{date(2024, 3, 10)},
+		},
+		{
+			name: "月の区切りを変更した月は期間の開始日時に発生する",
+			plan: models.Plan{Interval: 1, Start: datePtr(2024, 1, 10)},
+			from: time.Date(2024, 3, 31, 15, 0, 0, 0, time.UTC),
+			to:   date(2024, 4, 15),
+			want: []time.Time{time.Date(2024, 3, 31, 15, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "終了した計画は発生しない",
+			plan: models.Plan{Interval: 1, Start: datePtr(2024, 1, 1), End: datePtr(2024, 3, 1)},
+			from: date(2024, 3, 1),
+			to:   date(2024, 4, 1),
+			want: []time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Occurrences(&tt.plan, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    models.Plan
+		want    *models.Recurrence
+		changed bool
+	}{
+		{"旧データは毎月に移行する", models.Plan{Interval: 3}, &models.Recurrence{Frequency: FrequencyMonthly, Interval: 3}, true},
+		{"未設定の間隔は 1 か月とする", models.Plan{}, &models.Recurrence{Frequency: FrequencyMonthly, Interval: 1}, true},
+		{
+			"移行済みは変更しない",
+			models.Plan{Interval: 1, Recurrence: &models.Recurrence{Frequency: FrequencyWeekly, Interval: 2}},
+			&models.Recurrence{Frequency: FrequencyWeekly, Interval: 2},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tt.plan
+			if changed := MigrateRecurrence(&plan); changed != tt.changed {
+				t.Errorf("MigrateRecurrence() = %t, want %t", changed, tt.changed)
+			}
+			if !reflect.DeepEqual(plan.Recurrence, tt.want) {
+				t.Errorf("Recurrence = %+v, want %+v", plan.Recurrence, tt.want)
+			}
+		})
+	}
+}
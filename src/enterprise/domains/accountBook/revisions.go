@@ -0,0 +1,67 @@
+package accountbook
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// RevisionAt は指定月に有効な計画の改定を返します
+// 改定が登録されていない旧データは計画自身の値を返します
+func RevisionAt(plan *models.Plan, month time.Time) *models.PlanRevision {
+	var result *models.PlanRevision
+	for i := range plan.Revisions {
+		revision := &plan.Revisions[i]
+		if revision.EffectiveFrom.After(month) {
+			break
+		}
+		result = revision
+	}
+	if result == nil {
+		if len(plan.Revisions) > 0 {
+			// 最初の改定より前の月は最初の改定の値を使います
+			return &plan.Revisions[0]
+		}
+		return &models.PlanRevision{
+			PlanName:   plan.PlanName,
+			PlanAmount: plan.PlanAmount,
+			IsIncome:   plan.IsIncome,
+			CreatedAt:  plan.CreatedAt,
+		}
+	}
+	return result
+}
+
+// ApplyRevision は指定月に有効な改定の値を計画に反映します
+func ApplyRevision(plan *models.Plan, month time.Time) {
+	revision := RevisionAt(plan, month)
+	plan.PlanName = revision.PlanName
+	plan.PlanAmount = revision.PlanAmount
+	plan.IsIncome = revision.IsIncome
+}
+
+// AddRevision は計画に改定を追加します
+// 同じ適用開始日の改定は置き換え、改定は適用開始日の昇順に並べます
+func AddRevision(plan *models.Plan, revision models.PlanRevision) {
+	if len(plan.Revisions) == 0 {
+		// 旧データは現在の値を初期の改定として残します
+		plan.Revisions = []models.PlanRevision{{
+			PlanName:   plan.PlanName,
+			PlanAmount: plan.PlanAmount,
+			IsIncome:   plan.IsIncome,
+			CreatedAt:  plan.CreatedAt,
+		}}
+	}
+	revisions := make([]models.PlanRevision, 0, len(plan.Revisions)+1)
+	for _, r := range plan.Revisions {
+		if !r.EffectiveFrom.Equal(revision.EffectiveFrom) {
+			revisions = append(revisions, r)
+		}
+	}
+	revisions = append(revisions, revision)
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].EffectiveFrom.Before(revisions[j].EffectiveFrom)
+	})
+	plan.Revisions = revisions
+}
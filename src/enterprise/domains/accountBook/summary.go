@@ -0,0 +1,42 @@
+package accountbook
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/models"
+)
+
+// SumAdjustments は残高の調整額の合計を返します
+func SumAdjustments(adjustments []models.Adjustment) int {
+	sum := 0
+	for _, adjustment := range adjustments {
+		sum += adjustment.Amount
+	}
+	return sum
+}
+
+// SummarizeMonth は締め前の月の収入と支出を、計画・実績・取引からダッシュボードと同様に集計します
+// 実績が入力済みの計画は実績の金額を使います
+func SummarizeMonth(plans []models.Plan, actuals []models.Actual, transactions []models.Transaction) (int, int) {
+	income := 0
+	expense := 0
+	add := func(isIncome bool, amount int) {
+		if isIncome {
+			income += amount
+		} else {
+			expense += amount
+		}
+	}
+	captured := make(map[string]bool)
+	for _, actual := range actuals {
+		captured[actual.PlanID] = true
+		add(actual.IsIncome, actual.ActualAmount)
+	}
+	for _, plan := range plans {
+		if !captured[plan.PlanID] {
+			add(plan.IsIncome, plan.PlanAmount)
+		}
+	}
+	for _, transaction := range transactions {
+		add(transaction.Category == IncomeCategory, transaction.Amount)
+	}
+	return income, expense
+}
@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	channel struct {
+		value string
+	}
+	// Channel は通知の配信先を表す VO です
+	Channel interface {
+		Get() string
+		Valid() core.Error
+	}
+)
+
+const (
+	// EmailChannel はメールで配信します
+	EmailChannel = "Email"
+	// WebhookChannel は Webhook で配信します
+	WebhookChannel = "Webhook"
+	// InAppChannel はアプリ内の通知として配信します
+	InAppChannel = "InApp"
+	// NotSupportedChannelFormat :配信先としてサポートしていない形式です
+	NotSupportedChannelFormat core.ErrorCode = "notifications-00002"
+)
+
+// NewChannel は Channel を生成します
+func NewChannel(value string) (Channel, core.Error) {
+	ins := &channel{value}
+	if err := ins.Valid(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+
+// NewChannels は Channel の一覧を生成します
+// 指定が無い場合はアプリ内の通知のみとし、重複は取り除きます
+func NewChannels(values []string) ([]Channel, core.Error) {
+	if len(values) == 0 {
+		return []Channel{&channel{InAppChannel}}, nil
+	}
+	channels := make([]Channel, 0, len(values))
+	exists := make(map[string]bool)
+	for _, value := range values {
+		if exists[value] {
+			continue
+		}
+		ins, err := NewChannel(value)
+		if err != nil {
+			return nil, err
+		}
+		exists[value] = true
+		channels = append(channels, ins)
+	}
+	return channels, nil
+}
+func (t *channel) Get() string          { return t.value }
+func (t *channel) Equal(o Channel) bool { return t.value == o.Get() }
+func (t *channel) Valid() core.Error {
+	if t.value == EmailChannel || t.value == WebhookChannel || t.value == InAppChannel {
+		return nil
+	}
+	return core.NewError(NotSupportedChannelFormat)
+}
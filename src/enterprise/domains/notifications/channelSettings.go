@@ -0,0 +1,130 @@
+package notifications
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	channelSettings struct {
+		webhookURL    string
+		webhookSecret string
+	}
+	// ChannelSettings は配信先の設定の Entity です
+	ChannelSettings interface {
+		GetWebhookURL() string
+		SetWebhookURL(string) core.Error
+		GetWebhookSecret() string
+		SetWebhookSecret(string)
+		Valid() core.Error
+	}
+	// ChannelSettingsRepository は配信先の設定のリポジトリです
+	ChannelSettingsRepository interface {
+		Get() ChannelSettings
+		Save(channelSettings ChannelSettings)
+	}
+)
+
+const (
+	// InValidWebhookURL :Webhook の URL が不正です
+	InValidWebhookURL core.ErrorCode = "notifications-00004"
+	// RequiredWebhookSecret :Webhook の署名用のシークレットは必須です
+	RequiredWebhookSecret core.ErrorCode = "notifications-00005"
+)
+
+// NewChannelSettings は ChannelSettings を生成します
+// メールは確認済みのアカウントのメールアドレスにのみ配信するため、配信先の設定はありません
+func NewChannelSettings(webhookURL string, webhookSecret string) ChannelSettings {
+	return &channelSettings{webhookURL, webhookSecret}
+}
+func (t *channelSettings) GetWebhookURL() string { return t.webhookURL }
+func (t *channelSettings) SetWebhookURL(value string) core.Error {
+	t.webhookURL = value
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || !u.IsAbs() || u.Scheme != "https" || u.Hostname() == "" || !isPublicHost(u.Hostname()) {
+		return core.NewError(InValidWebhookURL)
+	}
+	return nil
+}
+func (t *channelSettings) GetWebhookSecret() string      { return t.webhookSecret }
+func (t *channelSettings) SetWebhookSecret(value string) { t.webhookSecret = value }
+
+// Valid は設定全体の整合性を検証します
+func (t *channelSettings) Valid() core.Error {
+	err := core.NewError()
+	if e := t.SetWebhookURL(t.webhookURL); e != nil {
+		err.Concat(e)
+	}
+	if t.webhookURL != "" && t.webhookSecret == "" {
+		err.Append(RequiredWebhookSecret)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+
+// privateNetworks はサーバー内部やローカルネットワークのアドレス範囲です
+var privateNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func parseCIDRs(values ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(values))
+	for i, value := range values {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// IsPublicIP はサーバーから配信してよい公開されたアドレスかどうかを返します
+// サーバー内部のサービスへ送信させないよう、ループバックやプライベート、リンクローカルのアドレスは含めません
+func IsPublicIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPublicHost はホスト名が公開されたホストを指すかどうかを返します
+// 名前解決の結果は配信時に検証するため、ここではアドレスの直接指定とローカルの名前のみを検証します
+func isPublicHost(host string) bool {
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	if name == "localhost" || strings.HasSuffix(name, ".localhost") || strings.HasSuffix(name, ".local") || strings.HasSuffix(name, ".internal") {
+		return false
+	}
+	if ip := net.ParseIP(name); ip != nil {
+		return IsPublicIP(ip)
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	cooldown struct {
+		hours int
+	}
+	// Cooldown は通知してから次に通知できるまでの時間を表す VO です
+	Cooldown interface {
+		Get() int
+		Valid() core.Error
+		Elapsed(lastFiredAt *time.Time, now time.Time) bool
+	}
+)
+
+const (
+	// MaxCooldownHours は指定できる時間(時間単位)の上限です
+	MaxCooldownHours = 24 * 31
+	// InValidCooldown :通知の間隔が不正です
+	InValidCooldown core.ErrorCode = "notifications-00013"
+)
+
+// NewCooldown は Cooldown を生成します
+// 0 の場合は間隔を空けずに通知します
+func NewCooldown(hours int) (Cooldown, core.Error) {
+	ins := &cooldown{hours}
+	if err := ins.Valid(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+func (t *cooldown) Get() int              { return t.hours }
+func (t *cooldown) Equal(o Cooldown) bool { return t.hours == o.Get() }
+func (t *cooldown) Valid() core.Error {
+	if t.hours < 0 || t.hours > MaxCooldownHours {
+		return core.NewError(InValidCooldown)
+	}
+	return nil
+}
+
+// Elapsed は前回の通知から次に通知できるまでの時間が経過したかどうかを返します
+func (t *cooldown) Elapsed(lastFiredAt *time.Time, now time.Time) bool {
+	if lastFiredAt == nil {
+		return true
+	}
+	return !now.Before(lastFiredAt.Add(time.Duration(t.hours) * time.Hour))
+}
@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	// InboxNotification はアプリ内の通知です
+	InboxNotification struct {
+		NotificationID     string
+		Title              string
+		Body               string
+		Severity           string
+		NotificationRuleID string
+		CreatedAt          time.Time
+		ReadAt             *time.Time
+	}
+	// InboxFilter はアプリ内の通知の取得条件です
+	InboxFilter struct {
+		Limit int
+		// Cursor は前のページの最後の通知の ID です
+		Cursor     *string
+		UnreadOnly bool
+	}
+	// InboxRepository はアプリ内の通知のリポジトリです
+	InboxRepository interface {
+		// Add は通知を保存します。保存できなかった場合は配信の失敗として再配信するため、エラーを返します
+		Add(notification *Notification) error
+		// Get は新しい順に通知を取得し、続きがある場合は次のページのカーソルを返します
+		Get(filter *InboxFilter) (*[]InboxNotification, *string, core.Error)
+		Read(id string) core.Error
+		ReadAll() int
+		CountUnread() int
+	}
+)
+
+// InValidCursor :カーソルが不正です
+const InValidCursor core.ErrorCode = "notifications-00006"
@@ -13,6 +13,7 @@ type (
 		Get() string
 		Set(string) core.Error
 		Valid() core.Error
+		Calculate(snapshot *Snapshot, period Period, category *int) int
 	}
 )
 
@@ -47,3 +48,27 @@ func (t *metrics) Valid() core.Error {
 	}
 	return core.NewError(NotSupportedMetricsFormat)
 }
+
+// Calculate は期間の収支からメトリクスの値を計算します
+// 月単位の支出と収支は計画の実績を含み、日・週単位は取引のみから計算します
+// 残高は期間に関わらず評価する時点の残高です
+func (t *metrics) Calculate(snapshot *Snapshot, period Period, category *int) int {
+	start, end := period.Range(snapshot)
+	isMonth := period.Get() == MonthPeriod
+	switch t.value {
+	case MonthlyBalanceBase:
+		if isMonth {
+			return snapshot.MonthlyBalance()
+		}
+		income, expense := snapshot.Sum(start, end, nil)
+		return income - expense
+	case TotalBalanceBase:
+		return snapshot.TotalBalance()
+	default:
+		if isMonth && category == nil {
+			return snapshot.Expense
+		}
+		_, expense := snapshot.Sum(start, end, category)
+		return expense
+	}
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestSnapshot() *Snapshot {
+	return &Snapshot{
+		Month:           time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		NextMonth:       time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Now:             time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC),
+		Income:          300000,
+		Expense:         120000,
+		PreviousBalance: 50000,
+		Transactions: []SnapshotTransaction{
+			{Date: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), Category: 1, Amount: 4000},
+			{Date: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), Category: 1, Amount: 1000},
+			{Date: time.Date(2024, 3, 13, 9, 0, 0, 0, time.UTC), Category: 2, Amount: 2000},
+			{Date: time.Date(2024, 3, 13, 10, 0, 0, 0, time.UTC), Category: 3, Amount: 500, IsIncome: true},
+		},
+	}
+}
+
+func TestMetricsCalculate(t *testing.T) {
+	category := 1
+	tests := []struct {
+		name     string
+		metrics  string
+		period   string
+		category *int
+		want     int
+	}{
+		{"支出基準 当月", ExpenseBase, MonthPeriod, nil, 120000},
+		{"支出基準 当月 カテゴリ指定", ExpenseBase, MonthPeriod, &category, 5000},
+		{"支出基準 当週", ExpenseBase, WeekPeriod, nil, 3000},
+		{"支出基準 当週 カテゴリ指定", ExpenseBase, WeekPeriod, &category, 1000},
+		{"支出基準 当日", ExpenseBase, DayPeriod, nil, 2000},
+		{"当月残高基準 当月", MonthlyBalanceBase, MonthPeriod, nil, 180000},
+		{"当月残高基準 当週", MonthlyBalanceBase, WeekPeriod, nil, -2500},
+		{"当月残高基準 当日", MonthlyBalanceBase, DayPeriod, nil, -1500},
+		{"残高基準 当月", TotalBalanceBase, MonthPeriod, nil, 230000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, err := NewMetrics(tt.metrics)
+			if err != nil {
+				t.Fatal(err)
+			}
+			period, err := NewPeriod(tt.period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := metrics.Calculate(newTestSnapshot(), period, tt.category); got != tt.want {
+				t.Errorf("Calculate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
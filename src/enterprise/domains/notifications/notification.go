@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Notification は通知ルールの閾値を超えたときに発行される通知の VO です
+	Notification struct {
+		NotificationRuleID NotificationRuleID
+		Metrics            string
+		Operator           string
+		Period             string
+		PeriodStart        time.Time
+		Category           *int
+		// Threshold は金額に換算した閾値です
+		Threshold int
+		Value     int
+		Month     time.Time
+		Channels  []string
+		// Attempts は配信に失敗した回数です
+		Attempts int
+	}
+	// Notifier は通知を配信します
+	// 配信に失敗した配信先と、失敗した場合はそのエラーを返します
+	Notifier interface {
+		Notify(notification *Notification) ([]string, error)
+	}
+)
+
+// MaxDeliveryAttempts は配信に失敗した通知を再配信する上限の回数です
+// 上限に達した通知は配信できない配信先への再配信を繰り返さないよう破棄します
+const MaxDeliveryAttempts = 5
+
+const (
+	// SeverityInfo はお知らせです
+	SeverityInfo = "Info"
+	// SeverityWarning は注意が必要な通知です
+	SeverityWarning = "Warning"
+	// SeverityCritical は早急な対応が必要な通知です
+	SeverityCritical = "Critical"
+)
+
+// Severity は通知の重要度です
+// 残高が閾値を下回った場合は資金不足の恐れがあるため最も重要とします
+func (t *Notification) Severity() string {
+	if t.Metrics == TotalBalanceBase {
+		return SeverityCritical
+	}
+	return SeverityWarning
+}
+
+// Title は通知の件名です
+func (t *Notification) Title() string {
+	verb := "が閾値に達しました"
+	switch t.Operator {
+	case BelowOperator:
+		verb = "が閾値を下回りました"
+	case CrossesOperator:
+		verb = "が閾値をまたぎました"
+	}
+	if t.Metrics == TotalBalanceBase {
+		return t.label() + verb
+	}
+	prefix := "今月の"
+	switch t.Period {
+	case DayPeriod:
+		prefix = "今日の"
+	case WeekPeriod:
+		prefix = "今週の"
+	}
+	return prefix + t.label() + verb
+}
+
+// Body は通知の本文です
+func (t *Notification) Body() string {
+	period := t.Month.Format("2006年1月")
+	switch t.Period {
+	case DayPeriod:
+		period = t.PeriodStart.Format("1月2日")
+	case WeekPeriod:
+		period = t.PeriodStart.Format("1月2日") + "からの週"
+	}
+	return fmt.Sprintf("%sの%sは%d円です。(閾値: %d円)", period, t.label(), t.Value, t.Threshold)
+}
+func (t *Notification) label() string {
+	switch t.Metrics {
+	case MonthlyBalanceBase:
+		return "収支"
+	case TotalBalanceBase:
+		return "残高"
+	default:
+		return "支出"
+	}
+}
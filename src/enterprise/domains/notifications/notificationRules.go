@@ -1,10 +1,26 @@
 package notifications
 
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
 type (
 	notificationRule struct {
-		id        NotificationRuleID
-		metrics   Metrics
-		threshold Threshold
+		id              NotificationRuleID
+		metrics         Metrics
+		threshold       Threshold
+		operator        Operator
+		period          Period
+		category        *int
+		channels        []Channel
+		rearm           bool
+		cooldown        Cooldown
+		exceededPeriod  *time.Time
+		lastObservation *Observation
+		lastFiredAt     *time.Time
+		pending         []Notification
 	}
 	// NotificationRuleID は通知ルールの ID です
 	NotificationRuleID *string
@@ -16,24 +32,155 @@ type (
 		SetMetrics(Metrics)
 		GetThreshold() Threshold
 		SetThreshold(Threshold)
+		GetOperator() Operator
+		SetOperator(Operator)
+		GetPeriod() Period
+		SetPeriod(Period)
+		GetCategory() *int
+		SetCategory(*int)
+		GetChannels() []Channel
+		SetChannels([]Channel)
+		GetRearm() bool
+		SetRearm(bool)
+		GetCooldown() Cooldown
+		SetCooldown(Cooldown)
+		GetExceededPeriod() *time.Time
+		SetExceededPeriod(*time.Time)
+		GetLastObservation() *Observation
+		SetLastObservation(*Observation)
+		GetLastFiredAt() *time.Time
+		SetLastFiredAt(*time.Time)
+		// GetPendingNotifications は配信に失敗し、再配信を待っている通知です
+		// 通知の配信先は配信に失敗した配信先のみです
+		GetPendingNotifications() []Notification
+		SetPendingNotifications([]Notification)
+		Valid() core.Error
+		Evaluate(snapshot *Snapshot) (*Notification, bool)
 		Equal(notificationRule NotificationRule) bool
 	}
+	// Observation は前回評価したときの期間と値の VO です
+	Observation struct {
+		Period time.Time
+		Value  int
+	}
 )
 
+// NotSupportedCondition :メトリクスと組み合わせられない条件です
+const NotSupportedCondition core.ErrorCode = "notifications-00012"
+
 // NewNotificationRule は通知ルールを生成します
+// 比較方法はメトリクスの既定の比較方法、期間は当月とします
+// 条件を満たさなくなったら再び通知できるようにし、通知の間隔は空けません
 func NewNotificationRule(
 	id NotificationRuleID,
 	metrics Metrics,
 	threshold Threshold,
+	channels []Channel,
 ) NotificationRule {
-	return &notificationRule{id, metrics, threshold}
+	return &notificationRule{
+		id:        id,
+		metrics:   metrics,
+		threshold: threshold,
+		operator:  DefaultOperator(metrics),
+		period:    &period{MonthPeriod},
+		channels:  channels,
+		rearm:     true,
+		cooldown:  &cooldown{0},
+	}
+}
+
+func (t *notificationRule) GetID() NotificationRuleID             { return t.id }
+func (t *notificationRule) GetMetrics() Metrics                   { return t.metrics }
+func (t *notificationRule) SetMetrics(value Metrics)              { t.metrics = value }
+func (t *notificationRule) GetThreshold() Threshold               { return t.threshold }
+func (t *notificationRule) SetThreshold(value Threshold)          { t.threshold = value }
+func (t *notificationRule) GetOperator() Operator                 { return t.operator }
+func (t *notificationRule) SetOperator(value Operator)            { t.operator = value }
+func (t *notificationRule) GetPeriod() Period                     { return t.period }
+func (t *notificationRule) SetPeriod(value Period)                { t.period = value }
+func (t *notificationRule) GetCategory() *int                     { return t.category }
+func (t *notificationRule) SetCategory(value *int)                { t.category = value }
+func (t *notificationRule) GetChannels() []Channel                { return t.channels }
+func (t *notificationRule) SetChannels(value []Channel)           { t.channels = value }
+func (t *notificationRule) GetRearm() bool                        { return t.rearm }
+func (t *notificationRule) SetRearm(value bool)                   { t.rearm = value }
+func (t *notificationRule) GetCooldown() Cooldown                 { return t.cooldown }
+func (t *notificationRule) SetCooldown(value Cooldown)            { t.cooldown = value }
+func (t *notificationRule) GetLastFiredAt() *time.Time            { return t.lastFiredAt }
+func (t *notificationRule) SetLastFiredAt(value *time.Time)       { t.lastFiredAt = value }
+func (t *notificationRule) GetExceededPeriod() *time.Time         { return t.exceededPeriod }
+func (t *notificationRule) SetExceededPeriod(value *time.Time)    { t.exceededPeriod = value }
+func (t *notificationRule) GetLastObservation() *Observation      { return t.lastObservation }
+func (t *notificationRule) SetLastObservation(value *Observation) { t.lastObservation = value }
+
+func (t *notificationRule) GetPendingNotifications() []Notification      { return t.pending }
+func (t *notificationRule) SetPendingNotifications(value []Notification) { t.pending = value }
+
+// Valid はメトリクスと条件の組み合わせを検証します
+// カテゴリは支出基準のみ、残高基準は期間を当月とする場合のみ指定できます
+func (t *notificationRule) Valid() core.Error {
+	if t.category != nil && t.metrics.Get() != ExpenseBase {
+		return core.NewError(NotSupportedCondition)
+	}
+	if t.metrics.Get() == TotalBalanceBase && t.period.Get() != MonthPeriod {
+		return core.NewError(NotSupportedCondition)
+	}
+	return nil
 }
 
-func (t *notificationRule) GetID() NotificationRuleID    { return t.id }
-func (t *notificationRule) GetMetrics() Metrics          { return t.metrics }
-func (t *notificationRule) SetMetrics(value Metrics)     { t.metrics = value }
-func (t *notificationRule) GetThreshold() Threshold      { return t.threshold }
-func (t *notificationRule) SetThreshold(value Threshold) { t.threshold = value }
+// Evaluate は期間の収支で通知ルールを評価し、新たに条件を満たした場合は通知を返します
+// 条件を満たした期間を記録し、同じ期間に満たしたままの間は再度通知しません
+// 再通知(rearm)が有効な場合は条件を満たさなくなった時点で記録を消し、再び満たしたときに通知します
+// Crosses は再通知が有効な場合は閾値をまたぐたびに、無効な場合は期間に 1 回だけ通知します
+// いずれの場合も前回の通知から間隔(cooldown)が経過するまでは通知しません
+// 2つ目の戻り値は記録した状態を変更したかどうかです
+func (t *notificationRule) Evaluate(snapshot *Snapshot) (*Notification, bool) {
+	start, _ := t.period.Range(snapshot)
+	value := t.metrics.Calculate(snapshot, t.period, t.category)
+	limit := t.threshold.Resolve(snapshot)
+
+	var previous *int
+	if t.lastObservation != nil && t.lastObservation.Period.Equal(start) {
+		previous = &t.lastObservation.Value
+	}
+	changed := previous == nil || *previous != value
+	t.lastObservation = &Observation{Period: start, Value: value}
+
+	crosses := t.operator.Get() == CrossesOperator
+	if !t.operator.Matches(previous, value, limit) {
+		if t.rearm && !crosses && t.exceededPeriod != nil {
+			t.exceededPeriod = nil
+			changed = true
+		}
+		return nil, changed
+	}
+	fired := t.exceededPeriod != nil && t.exceededPeriod.Equal(start)
+	if fired && (!crosses || !t.rearm) {
+		return nil, changed
+	}
+	if !t.cooldown.Elapsed(t.lastFiredAt, snapshot.Now) {
+		return nil, changed
+	}
+	now := snapshot.Now
+	t.exceededPeriod = &start
+	t.lastFiredAt = &now
+	channels := make([]string, len(t.channels))
+	for i, channel := range t.channels {
+		channels[i] = channel.Get()
+	}
+	return &Notification{
+		NotificationRuleID: t.id,
+		Metrics:            t.metrics.Get(),
+		Operator:           t.operator.Get(),
+		Period:             t.period.Get(),
+		PeriodStart:        start,
+		Category:           t.category,
+		Threshold:          limit,
+		Value:              value,
+		Month:              snapshot.Month,
+		Channels:           channels,
+	}, true
+}
 func (t *notificationRule) Equal(notificationRule NotificationRule) bool {
 	id := t.id
 	aID := notificationRule.GetID()
@@ -7,8 +7,11 @@ type (
 	NotificationRulesRepository interface {
 		Get() *[]NotificationRule
 		GetByID(id NotificationRuleID) (NotificationRule, core.Error)
-		New(metrics Metrics, threshold Threshold) NotificationRule
+		New(notificationRule NotificationRule) NotificationRule
 		Save(notificationRule NotificationRule)
+		// Modify は通知ルールを読み込んでから書き込むまでを排他的に行います
+		// modify が true を返した場合のみ書き込みます
+		Modify(id NotificationRuleID, modify func(notificationRule NotificationRule) bool) core.Error
 		Delete(id NotificationRuleID) core.Error
 	}
 )
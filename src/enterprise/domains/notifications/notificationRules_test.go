@@ -0,0 +1,161 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationRuleEvaluate(t *testing.T) {
+	metrics, err := NewMetrics(ExpenseBase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	threshold, err := NewThreshold(10000, AmountUnit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	channels, err := NewChannels(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := "rule"
+	rule := NewNotificationRule(&id, metrics, threshold, channels)
+	month := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// 同じルールを順に評価するため、各ステップは前のステップの状態を引き継ぎます
+	steps := []struct {
+		name         string
+		expense      int
+		wantNotify   bool
+		wantExceeded bool
+	}{
+		{"閾値未満は通知しない", 5000, false, false},
+		{"初めて超えたら通知する", 12000, true, true},
+		{"超えたままなら通知しない", 15000, false, true},
+		{"下回ったら記録を消す", 8000, false, false},
+		{"再び超えたら通知する", 11000, true, true},
+	}
+	for i, step := range steps {
+		snapshot := &Snapshot{
+			Month:   month,
+			Now:     month.AddDate(0, 0, i),
+			Expense: step.expense,
+		}
+		notification, _ := rule.Evaluate(snapshot)
+		if got := notification != nil; got != step.wantNotify {
+			t.Fatalf("%s: notify = %t, want %t", step.name, got, step.wantNotify)
+		}
+		if notification != nil && (notification.Value != step.expense || notification.Threshold != 10000) {
+			t.Errorf("%s: notification = %+v", step.name, notification)
+		}
+		exceeded := rule.GetExceededPeriod()
+		if got := exceeded != nil; got != step.wantExceeded {
+			t.Fatalf("%s: exceededPeriod = %v, want set %t", step.name, exceeded, step.wantExceeded)
+		}
+		if exceeded != nil && !exceeded.Equal(month) {
+			t.Errorf("%s: exceededPeriod = %v, want %v", step.name, exceeded, month)
+		}
+	}
+}
+
+func TestNotificationRuleEvaluateRearmAndCooldown(t *testing.T) {
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	type step struct {
+		month      time.Time
+		now        time.Time
+		expense    int
+		wantNotify bool
+	}
+	tests := []struct {
+		name     string
+		operator string
+		rearm    bool
+		cooldown int
+		steps    []step
+	}{
+		{"間隔が経過するまでは再び超えても通知しない", AboveOperator, true, 24, []step{
+			{march, march.Add(1 * time.Hour), 12000, true},
+			{march, march.Add(2 * time.Hour), 8000, false},
+			{march, march.Add(3 * time.Hour), 12000, false},
+			{march, march.Add(25 * time.Hour), 13000, true},
+		}},
+		{"再通知が無効な場合は期間に 1 回だけ通知する", AboveOperator, false, 0, []step{
+			{march, march.Add(1 * time.Hour), 12000, true},
+			{march, march.Add(2 * time.Hour), 8000, false},
+			{march, march.Add(3 * time.Hour), 12000, false},
+			{april, april.Add(1 * time.Hour), 12000, true},
+		}},
+		{"超えたまま期間が変わったら通知する", AboveOperator, true, 0, []step{
+			{march, march.Add(1 * time.Hour), 12000, true},
+			{march, march.Add(2 * time.Hour), 15000, false},
+			{april, april.Add(1 * time.Hour), 12000, true},
+		}},
+		{"Crosses 再通知が有効な場合はまたぐたびに通知する", CrossesOperator, true, 0, []step{
+			{march, march.Add(1 * time.Hour), 5000, false},
+			{march, march.Add(2 * time.Hour), 12000, true},
+			{march, march.Add(3 * time.Hour), 8000, true},
+			{march, march.Add(4 * time.Hour), 9000, false},
+			{march, march.Add(5 * time.Hour), 11000, true},
+		}},
+		{"Crosses 再通知が無効な場合は期間に 1 回だけ通知する", CrossesOperator, false, 0, []step{
+			{march, march.Add(1 * time.Hour), 5000, false},
+			{march, march.Add(2 * time.Hour), 12000, true},
+			{march, march.Add(3 * time.Hour), 8000, false},
+			{march, march.Add(4 * time.Hour), 12000, false},
+			{april, april.Add(1 * time.Hour), 12000, false},
+			{april, april.Add(2 * time.Hour), 5000, true},
+		}},
+		{"Crosses 間隔が経過するまではまたいでも通知しない", CrossesOperator, true, 24, []step{
+			{march, march.Add(1 * time.Hour), 5000, false},
+			{march, march.Add(2 * time.Hour), 12000, true},
+			{march, march.Add(3 * time.Hour), 8000, false},
+			{march, march.Add(26 * time.Hour), 12000, true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics, err := NewMetrics(ExpenseBase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			threshold, err := NewThreshold(10000, AmountUnit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			channels, err := NewChannels(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			operator, err := NewOperator(tt.operator)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cooldown, err := NewCooldown(tt.cooldown)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := "rule"
+			rule := NewNotificationRule(&id, metrics, threshold, channels)
+			rule.SetOperator(operator)
+			rule.SetRearm(tt.rearm)
+			rule.SetCooldown(cooldown)
+
+			// 同じルールを順に評価するため、各ステップは前のステップの状態を引き継ぎます
+			for i, step := range tt.steps {
+				snapshot := &Snapshot{
+					Month:   step.month,
+					Now:     step.now,
+					Expense: step.expense,
+				}
+				notification, _ := rule.Evaluate(snapshot)
+				if got := notification != nil; got != step.wantNotify {
+					t.Fatalf("step %d: notify = %t, want %t", i, got, step.wantNotify)
+				}
+				if notification != nil && !notification.PeriodStart.Equal(step.month) {
+					t.Errorf("step %d: periodStart = %v, want %v", i, notification.PeriodStart, step.month)
+				}
+			}
+		})
+	}
+}
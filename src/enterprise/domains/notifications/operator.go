@@ -0,0 +1,67 @@
+package notifications
+
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	operator struct {
+		value string
+	}
+	// Operator は値と閾値の比較方法を表す VO です
+	Operator interface {
+		Get() string
+		Valid() core.Error
+		Matches(previous *int, value int, limit int) bool
+	}
+)
+
+const (
+	// AboveOperator は値が閾値以上になったときに通知します
+	AboveOperator = "Above"
+	// BelowOperator は値が閾値を下回ったときに通知します
+	BelowOperator = "Below"
+	// CrossesOperator は値が閾値をまたいだときに通知します
+	CrossesOperator = "Crosses"
+	// NotSupportedOperatorFormat :比較方法としてサポートしていない形式です
+	NotSupportedOperatorFormat core.ErrorCode = "notifications-00007"
+)
+
+// NewOperator は Operator を生成します
+func NewOperator(value string) (Operator, core.Error) {
+	ins := &operator{value}
+	if err := ins.Valid(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+
+// DefaultOperator は比較方法を指定しないルールの比較方法です
+// 支出基準は閾値以上、残高基準は閾値を下回ったときに通知します
+func DefaultOperator(metrics Metrics) Operator {
+	if metrics.Get() == ExpenseBase {
+		return &operator{AboveOperator}
+	}
+	return &operator{BelowOperator}
+}
+func (t *operator) Get() string           { return t.value }
+func (t *operator) Equal(o Operator) bool { return t.value == o.Get() }
+func (t *operator) Valid() core.Error {
+	if t.value == AboveOperator || t.value == BelowOperator || t.value == CrossesOperator {
+		return nil
+	}
+	return core.NewError(NotSupportedOperatorFormat)
+}
+
+// Matches は値が条件を満たすかどうかを返します
+// Crosses は同じ期間の前回の値が無い場合は満たさないものとします
+func (t *operator) Matches(previous *int, value int, limit int) bool {
+	switch t.value {
+	case AboveOperator:
+		return value >= limit
+	case BelowOperator:
+		return value < limit
+	default:
+		return previous != nil && (*previous >= limit) != (value >= limit)
+	}
+}
@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
+type (
+	period struct {
+		value string
+	}
+	// Period は評価する期間を表す VO です
+	Period interface {
+		Get() string
+		Valid() core.Error
+		Range(snapshot *Snapshot) (time.Time, time.Time)
+	}
+)
+
+const (
+	// DayPeriod は当日で評価します
+	DayPeriod = "Day"
+	// WeekPeriod は月曜日から始まる当週で評価します
+	WeekPeriod = "Week"
+	// MonthPeriod は当月で評価します
+	MonthPeriod = "Month"
+	// NotSupportedPeriodFormat :期間としてサポートしていない形式です
+	NotSupportedPeriodFormat core.ErrorCode = "notifications-00008"
+)
+
+// NewPeriod は Period を生成します
+func NewPeriod(value string) (Period, core.Error) {
+	ins := &period{value}
+	if err := ins.Valid(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+func (t *period) Get() string         { return t.value }
+func (t *period) Equal(o Period) bool { return t.value == o.Get() }
+func (t *period) Valid() core.Error {
+	if t.value == DayPeriod || t.value == WeekPeriod || t.value == MonthPeriod {
+		return nil
+	}
+	return core.NewError(NotSupportedPeriodFormat)
+}
+
+// Range は評価する時点を含む期間の開始日時と終了日時(含まない)を返します
+func (t *period) Range(snapshot *Snapshot) (time.Time, time.Time) {
+	now := snapshot.Now
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch t.value {
+	case DayPeriod:
+		return today, today.AddDate(0, 0, 1)
+	case WeekPeriod:
+		start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	default:
+		return snapshot.Month, snapshot.NextMonth
+	}
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import "time"
+
+type (
+	// Snapshot は通知ルールの評価に使う当月の収支の VO です
+	Snapshot struct {
+		Month time.Time
+		// NextMonth は翌月の開始日です。月の区切りを変更した月は 1 か月と長さが異なります
+		NextMonth       time.Time
+		Now             time.Time
+		Income          int
+		Expense         int
+		PreviousBalance int
+		// Transactions は当週の初めから当月の終わりまでの取引です
+		Transactions []SnapshotTransaction
+	}
+	// SnapshotTransaction は評価に使う取引の VO です
+	SnapshotTransaction struct {
+		Date     time.Time
+		Category int
+		Amount   int
+		IsIncome bool
+		// Uncategorized は未分類の取引かどうかです。カテゴリを指定した評価には含めません
+		Uncategorized bool
+	}
+)
+
+// MonthlyBalance は当月の収支を返します
+func (t *Snapshot) MonthlyBalance() int { return t.Income - t.Expense }
+
+// TotalBalance は前月までの残高に当月の収支を加えた残高を返します
+func (t *Snapshot) TotalBalance() int { return t.PreviousBalance + t.MonthlyBalance() }
+
+// Sum は期間内の取引の収入と支出を返します
+// category を指定した場合はそのカテゴリの取引のみを集計します
+func (t *Snapshot) Sum(start time.Time, end time.Time, category *int) (int, int) {
+	income := 0
+	expense := 0
+	for _, transaction := range t.Transactions {
+		if transaction.Date.Before(start) || !transaction.Date.Before(end) {
+			continue
+		}
+		if category != nil && (transaction.Uncategorized || transaction.Category != *category) {
+			continue
+		}
+		if transaction.IsIncome {
+			income += transaction.Amount
+		} else {
+			expense += transaction.Amount
+		}
+	}
+	return income, expense
+}
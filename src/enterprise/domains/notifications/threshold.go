@@ -1,18 +1,79 @@
 package notifications
 
+import (
+	"github.com/wakuwaku3/account-book.api/src/enterprise/core"
+)
+
 type (
 	threshold struct {
 		value int
+		unit  string
 	}
 	// Threshold は閾値の VO です
 	Threshold interface {
 		Get() int
 		Set(int)
+		GetUnit() string
+		Valid() core.Error
+		Resolve(snapshot *Snapshot) int
 	}
 )
 
+const (
+	// AmountUnit は閾値を金額で指定します
+	AmountUnit = "Amount"
+	// PercentOfIncomeUnit は閾値を当月の収入に対する割合(%)で指定します
+	PercentOfIncomeUnit = "PercentOfIncome"
+	// MaxPercentOfIncome は収入に対する割合で指定できる上限です
+	MaxPercentOfIncome = 1000
+	// NotSupportedThresholdUnit :閾値の単位としてサポートしていない形式です
+	NotSupportedThresholdUnit core.ErrorCode = "notifications-00009"
+	// NegativeThreshold :閾値は0以上である必要があります
+	NegativeThreshold core.ErrorCode = "notifications-00010"
+	// TooLargePercentOfIncome :収入に対する割合が上限を超えています
+	TooLargePercentOfIncome core.ErrorCode = "notifications-00011"
+)
+
 // NewThreshold は Threshold を生成します
-func NewThreshold(value int) Threshold      { return &threshold{value} }
-func (t *threshold) Get() int               { return t.value }
-func (t *threshold) Set(value int)          { t.value = value }
-func (t *threshold) Equal(o Threshold) bool { return t.value == o.Get() }
+// 単位を指定しない場合は金額とします
+func NewThreshold(value int, unit string) (Threshold, core.Error) {
+	if unit == "" {
+		unit = AmountUnit
+	}
+	ins := &threshold{value, unit}
+	if err := ins.Valid(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+func (t *threshold) Get() int        { return t.value }
+func (t *threshold) Set(value int)   { t.value = value }
+func (t *threshold) GetUnit() string { return t.unit }
+func (t *threshold) Equal(o Threshold) bool {
+	return t.value == o.Get() && t.unit == o.GetUnit()
+}
+func (t *threshold) Valid() core.Error {
+	err := core.NewError()
+	if t.unit != AmountUnit && t.unit != PercentOfIncomeUnit {
+		err.Append(NotSupportedThresholdUnit)
+	}
+	if t.value < 0 {
+		err.Append(NegativeThreshold)
+	}
+	if t.unit == PercentOfIncomeUnit && t.value > MaxPercentOfIncome {
+		err.Append(TooLargePercentOfIncome)
+	}
+	if err.HasError() {
+		return err
+	}
+	return nil
+}
+
+// Resolve は閾値を金額で返します
+// 収入に対する割合は、評価する期間に関わらず当月の収入に対して計算します
+func (t *threshold) Resolve(snapshot *Snapshot) int {
+	if t.unit == PercentOfIncomeUnit {
+		return snapshot.Income * t.value / 100
+	}
+	return t.value
+}
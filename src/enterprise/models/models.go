@@ -31,41 +31,166 @@ type (
 		Email        string    `firestore:"email"`
 		Culture      string    `firestore:"culture"`
 		UseStartDate time.Time `firestore:"useStartDate"`
+		// MonthStartDay は月の開始日です。0 の場合は 1 日とします
+		MonthStartDay int `firestore:"monthStartDay"`
+		// TimeZone は IANA のタイムゾーン名です。空の場合は既定のタイムゾーンとします
+		TimeZone string `firestore:"timeZone"`
+		// WeeklyDigest は週次のまとめメールを受け取るかどうかです
+		WeeklyDigest bool `firestore:"weeklyDigest"`
+		// DigestWeekday は週次のまとめメールを受け取る曜日です。0 が日曜日です
+		DigestWeekday int `firestore:"digestWeekday"`
+		// MonthEndDigest は月末のお知らせメールを受け取るかどうかです
+		MonthEndDigest       bool       `firestore:"monthEndDigest"`
+		LastWeeklyDigestAt   *time.Time `firestore:"lastWeeklyDigestAt"`
+		LastMonthEndDigestAt *time.Time `firestore:"lastMonthEndDigestAt"`
+		// MonthSettingsHistory は変更前の月の区切りです。Until の昇順に並べます
+		MonthSettingsHistory []MonthSettings `firestore:"monthSettingsHistory"`
+	}
+	// MonthSettings は変更前の月の区切りです
+	MonthSettings struct {
+		// Until は変更後の月の区切りを適用する日時です
+		Until         time.Time `firestore:"until"`
+		MonthStartDay int       `firestore:"monthStartDay"`
+		TimeZone      string    `firestore:"timeZone"`
+	}
+	// MonthBoundary は月の区切りを変更する時に読み書きする状態です
+	MonthBoundary struct {
+		User User
+		// LatestClosed は最後に締めた月のダッシュボードです
+		LatestClosed *Dashboard
+		// Open は実績を入力中の月のダッシュボードです。日付の昇順に並べます
+		Open []Dashboard
+		// ClearAggregates は月毎の集計を削除するかどうかです
+		ClearAggregates bool
 	}
 	// Plan は計画です
 	Plan struct {
-		PlanID     string     `firestore:"-"`
-		PlanName   string     `firestore:"planName"`
+		PlanID     string         `firestore:"-"`
+		PlanName   string         `firestore:"planName"`
+		Interval   int            `firestore:"interval"`
+		Recurrence *Recurrence    `firestore:"recurrence"`
+		PlanAmount int            `firestore:"planAmount"`
+		IsIncome   bool           `firestore:"isIncome"`
+		Start      *time.Time     `firestore:"start"`
+		End        *time.Time     `firestore:"end"`
+		Revisions  []PlanRevision `firestore:"revisions"`
+		IsDeleted  bool           `firestore:"isDeleted"`
+		DeletedAt  *time.Time     `firestore:"deletedAt"`
+		CreatedAt  time.Time      `firestore:"createdAt"`
+	}
+	// PlanRevision は適用開始日付きの計画の改定です
+	PlanRevision struct {
+		EffectiveFrom time.Time `firestore:"effectiveFrom"`
+		PlanName      string    `firestore:"planName"`
+		PlanAmount    int       `firestore:"planAmount"`
+		IsIncome      bool      `firestore:"isIncome"`
+		CreatedAt     time.Time `firestore:"createdAt"`
+	}
+	// Recurrence は計画の繰り返し規則です
+	Recurrence struct {
+		Frequency  string     `firestore:"frequency"`
 		Interval   int        `firestore:"interval"`
-		PlanAmount int        `firestore:"planAmount"`
-		IsIncome   bool       `firestore:"isIncome"`
-		Start      *time.Time `firestore:"start"`
-		End        *time.Time `firestore:"end"`
-		IsDeleted  bool       `firestore:"isDeleted"`
-		CreatedAt  time.Time  `firestore:"createdAt"`
+		ByMonth    []int      `firestore:"byMonth"`
+		ByMonthDay []int      `firestore:"byMonthDay"`
+		Count      *int       `firestore:"count"`
+		Until      *time.Time `firestore:"until"`
 	}
 	// Transaction は取引です
 	Transaction struct {
-		TransactionID string    `firestore:"-"`
-		Amount        int       `firestore:"amount"`
-		Category      int       `firestore:"category"`
-		Date          time.Time `firestore:"date"`
-		Notes         *string   `firestore:"notes"`
-		DailyID       *string   `firestore:"dailyId"`
+		TransactionID   string    `firestore:"-"`
+		Amount          int       `firestore:"amount"`
+		Category        int       `firestore:"category"`
+		Date            time.Time `firestore:"date"`
+		Notes           *string   `firestore:"notes"`
+		ImportReference *string   `firestore:"importReference"`
+		ImportSource    *string   `firestore:"importSource"`
+		PayeeID         *string   `firestore:"payeeId"`
+		Tags            []string  `firestore:"tags"`
+		DailyID         *string   `firestore:"dailyId"`
+		// Uncategorized はカテゴリを省略して登録した未分類の取引かどうかです
+		// 未分類の間は Category を使わず、自動分類のルールでカテゴリを設定します
+		Uncategorized bool `firestore:"uncategorized"`
+	}
+	// Payee は支払先です
+	Payee struct {
+		PayeeID   string    `firestore:"-"`
+		PayeeName string    `firestore:"payeeName"`
+		CreatedAt time.Time `firestore:"createdAt"`
+	}
+	// CategorizationRule は取引を自動分類するルールです
+	CategorizationRule struct {
+		RuleID       string    `firestore:"-"`
+		RuleName     string    `firestore:"ruleName"`
+		Priority     int       `firestore:"priority"`
+		NotesPattern *string   `firestore:"notesPattern"`
+		PayeePattern *string   `firestore:"payeePattern"`
+		MinAmount    *int      `firestore:"minAmount"`
+		MaxAmount    *int      `firestore:"maxAmount"`
+		ImportSource *string   `firestore:"importSource"`
+		Category     *int      `firestore:"category"`
+		Tags         []string  `firestore:"tags"`
+		PayeeID      *string   `firestore:"payeeId"`
+		CreatedAt    time.Time `firestore:"createdAt"`
+	}
+	// Budget はカテゴリ毎の月の予算です
+	Budget struct {
+		BudgetID  string    `firestore:"-"`
+		Category  int       `firestore:"category"`
+		Month     time.Time `firestore:"month"`
+		Amount    int       `firestore:"amount"`
+		Rollover  bool      `firestore:"rollover"`
+		CreatedAt time.Time `firestore:"createdAt"`
+	}
+	// Attachment は取引の添付ファイルです
+	Attachment struct {
+		AttachmentID string    `firestore:"-"`
+		FileName     string    `firestore:"fileName"`
+		ContentType  string    `firestore:"contentType"`
+		Size         int       `firestore:"size"`
+		HasThumbnail bool      `firestore:"hasThumbnail"`
+		CreatedAt    time.Time `firestore:"createdAt"`
 	}
 	// Dashboard はダッシュボードです
 	Dashboard struct {
-		DashboardID         string    `firestore:"-"`
-		Date                time.Time `firestore:"date"`
-		Income              *int      `firestore:"income"`
-		Expense             *int      `firestore:"expense"`
-		CurrentBalance      *int      `firestore:"currentBalance"`
-		Balance             *int      `firestore:"balance"`
-		PreviousDashboardID *string   `firestore:"previousDashboardId"`
-		PreviousBalance     *int      `firestore:"previousBalance"`
-		State               string    `firestore:"state"`
-		Daily               []Daily   `firestore:"-"`
-		Actual              []Actual  `firestore:"-"`
+		DashboardID         string            `firestore:"-"`
+		Date                time.Time         `firestore:"date"`
+		Income              *int              `firestore:"income"`
+		Expense             *int              `firestore:"expense"`
+		CurrentBalance      *int              `firestore:"currentBalance"`
+		Balance             *int              `firestore:"balance"`
+		PreviousDashboardID *string           `firestore:"previousDashboardId"`
+		PreviousBalance     *int              `firestore:"previousBalance"`
+		State               string            `firestore:"state"`
+		Daily               []Daily           `firestore:"-"`
+		Actual              []Actual          `firestore:"-"`
+		Categories          []CategorySummary `firestore:"-"`
+		Adjustments         []Adjustment      `firestore:"-"`
+	}
+	// Adjustment は残高の調整です
+	Adjustment struct {
+		AdjustmentID string    `firestore:"-"`
+		Amount       int       `firestore:"amount"`
+		Reason       string    `firestore:"reason"`
+		CreatedAt    time.Time `firestore:"createdAt"`
+	}
+	// CategorySummary はカテゴリ毎の支出の集計です
+	CategorySummary struct {
+		CategorySummaryID string `firestore:"-"`
+		Category          int    `firestore:"category"`
+		Amount            int    `firestore:"amount"`
+		Count             int    `firestore:"count"`
+		// Uncategorized は未分類の取引の集計かどうかです。未分類の取引は Category が 0 のカテゴリと分けて集計します
+		Uncategorized bool `firestore:"uncategorized"`
+	}
+	// Aggregate は締め前の月の取引の集計です
+	Aggregate struct {
+		AggregateID string            `firestore:"-"`
+		Month       time.Time         `firestore:"month"`
+		Income      int               `firestore:"income"`
+		Expense     int               `firestore:"expense"`
+		Daily       []Daily           `firestore:"daily"`
+		Categories  []CategorySummary `firestore:"categories"`
+		UpdatedAt   time.Time         `firestore:"updatedAt"`
 	}
 	// Daily は日毎のデータです
 	Daily struct {